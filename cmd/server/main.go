@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 
+	"github.com/Durgarao310/zneha-backend/internal/config"
 	"github.com/Durgarao310/zneha-backend/internal/container"
 	"github.com/Durgarao310/zneha-backend/internal/database"
 	"github.com/Durgarao310/zneha-backend/internal/server"
@@ -22,11 +23,17 @@ func main() {
 	}
 	defer zapLogger.Sync()
 
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
 	// Initialize database
 	db := database.InitPostgres()
 
 	// Initialize dependency container
-	appContainer := container.NewContainer(db)
+	appContainer := container.NewContainer(db, cfg)
 
 	// Initialize server
 	appServer := server.NewServer(appContainer, appLogger)
@@ -35,7 +42,7 @@ func main() {
 	appServer.SetupRouter()
 
 	// Start server
-	if err := appServer.Start("8080"); err != nil {
+	if err := appServer.Start(cfg.Server.Port); err != nil {
 		appLogger.Error("Failed to start server", "error", err)
 	}
 }
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/Durgarao310/zneha-backend/internal/api/middleware"
+	"github.com/Durgarao310/zneha-backend/internal/dto"
+	"github.com/Durgarao310/zneha-backend/internal/service"
+	"github.com/Durgarao310/zneha-backend/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+type AccountController struct {
+	accountService service.AccountService
+}
+
+func NewAccountController(accountService service.AccountService) *AccountController {
+	return &AccountController{
+		accountService: accountService,
+	}
+}
+
+// Export returns a JSON archive of the caller's personal data
+func (c *AccountController) Export(ctx *gin.Context) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	data, err := c.accountService.ExportData(userID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="account-%d.json"`, userID))
+	api.SendSuccess(ctx, http.StatusOK, dto.ToAccountExportResponse(data))
+}
+
+// Delete erases the caller's account and signs out every session
+func (c *AccountController) Delete(ctx *gin.Context) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req dto.DeleteAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.accountService.DeleteAccount(userID, req.Password, req.Code, clientInfo(ctx)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusNoContent, struct{}{})
+}
+
+// handleError maps account service errors to HTTP responses
+func (c *AccountController) handleError(ctx *gin.Context, err error) {
+	var throttleErr *service.ThrottleError
+	if errors.As(err, &throttleErr) {
+		retryAfter := int(math.Ceil(throttleErr.RetryAfter.Seconds()))
+		ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+		ctx.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error(), "retryAfter": retryAfter})
+		return
+	}
+
+	switch {
+	case errors.Is(err, service.ErrPasswordRequired),
+		errors.Is(err, service.ErrTwoFactorCodeRequired):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidCredentials),
+		errors.Is(err, service.ErrInvalidTwoFactorCode):
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrUserNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
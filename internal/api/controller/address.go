@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Durgarao310/zneha-backend/internal/api/middleware"
+	"github.com/Durgarao310/zneha-backend/internal/dto"
+	"github.com/Durgarao310/zneha-backend/internal/service"
+	"github.com/Durgarao310/zneha-backend/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+type AddressController struct {
+	addressService service.AddressService
+}
+
+func NewAddressController(addressService service.AddressService) *AddressController {
+	return &AddressController{
+		addressService: addressService,
+	}
+}
+
+func (c *AddressController) List(ctx *gin.Context) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	addresses, err := c.addressService.ListAddresses(userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, addresses)
+}
+
+func (c *AddressController) Get(ctx *gin.Context) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid address ID"})
+		return
+	}
+
+	address, err := c.addressService.GetAddress(userID, id)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, address)
+}
+
+func (c *AddressController) Create(ctx *gin.Context) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req dto.AddressRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	address := req.ToModel()
+	if err := c.addressService.CreateAddress(userID, address); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusCreated, address)
+}
+
+func (c *AddressController) Update(ctx *gin.Context) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid address ID"})
+		return
+	}
+
+	var req dto.AddressRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	address, err := c.addressService.UpdateAddress(userID, id, req.ToModel())
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, address)
+}
+
+func (c *AddressController) Delete(ctx *gin.Context) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid address ID"})
+		return
+	}
+
+	if err := c.addressService.DeleteAddress(userID, id); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusNoContent, struct{}{})
+}
+
+// handleError maps address service errors to HTTP responses
+func (c *AddressController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrAddressNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrAddressLimit),
+		errors.Is(err, service.ErrInvalidPhone),
+		errors.Is(err, service.ErrInvalidAddressPin):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Durgarao310/zneha-backend/internal/api/middleware"
+	"github.com/Durgarao310/zneha-backend/internal/dto"
+	"github.com/Durgarao310/zneha-backend/internal/service"
+	"github.com/Durgarao310/zneha-backend/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyController struct {
+	apiKeyService service.APIKeyService
+}
+
+func NewAPIKeyController(apiKeyService service.APIKeyService) *APIKeyController {
+	return &APIKeyController{
+		apiKeyService: apiKeyService,
+	}
+}
+
+func (c *APIKeyController) CreateKey(ctx *gin.Context) {
+	var req dto.APIKeyCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var createdByID *uint64
+	if userID, ok := middleware.GetUserID(ctx); ok {
+		createdByID = &userID
+	}
+
+	key, plain, err := c.apiKeyService.CreateKey(req.Name, req.Scopes, req.ExpiresAt, createdByID, middleware.GetPermissions(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnknownPermission), errors.Is(err, service.ErrAPIKeyExpired):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, service.ErrScopeNotHeld):
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusCreated, dto.APIKeyCreatedResponse{Key: plain, APIKey: *key})
+}
+
+func (c *APIKeyController) ListKeys(ctx *gin.Context) {
+	keys, err := c.apiKeyService.ListKeys()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, keys)
+}
+
+func (c *APIKeyController) RevokeKey(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid API key ID"})
+		return
+	}
+
+	if err := c.apiKeyService.RevokeKey(id); err != nil {
+		if errors.Is(err, service.ErrAPIKeyNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusNoContent, struct{}{})
+}
@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Durgarao310/zneha-backend/internal/dto"
+	"github.com/Durgarao310/zneha-backend/internal/service"
+	"github.com/Durgarao310/zneha-backend/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+type AttributeController struct {
+	attributeService service.AttributeService
+}
+
+func NewAttributeController(attributeService service.AttributeService) *AttributeController {
+	return &AttributeController{
+		attributeService: attributeService,
+	}
+}
+
+func (c *AttributeController) ListAttributes(ctx *gin.Context) {
+	attributes, err := c.attributeService.ListAttributes()
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, attributes)
+}
+
+func (c *AttributeController) GetAttribute(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", "Invalid attribute ID")
+	if !ok {
+		return
+	}
+
+	attribute, err := c.attributeService.GetAttribute(id)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, attribute)
+}
+
+func (c *AttributeController) CreateAttribute(ctx *gin.Context) {
+	var req dto.AttributeCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attribute := req.ToModel()
+	if err := c.attributeService.CreateAttribute(attribute); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusCreated, attribute)
+}
+
+func (c *AttributeController) UpdateAttribute(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", "Invalid attribute ID")
+	if !ok {
+		return
+	}
+
+	var req dto.AttributeUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attribute := req.ToModel(id)
+	if err := c.attributeService.UpdateAttribute(attribute); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, attribute)
+}
+
+func (c *AttributeController) DeleteAttribute(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", "Invalid attribute ID")
+	if !ok {
+		return
+	}
+
+	if err := c.attributeService.DeleteAttribute(id); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusNoContent, struct{}{})
+}
+
+// GetCategoryAttributes returns the attributes that apply to a category, including inherited ones
+func (c *AttributeController) GetCategoryAttributes(ctx *gin.Context) {
+	categoryID, ok := idParam(ctx, "id", "Invalid category ID")
+	if !ok {
+		return
+	}
+
+	links, err := c.attributeService.GetCategoryAttributes(categoryID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, links)
+}
+
+// SetCategoryAttributes replaces the attributes assigned directly to a category
+func (c *AttributeController) SetCategoryAttributes(ctx *gin.Context) {
+	categoryID, ok := idParam(ctx, "id", "Invalid category ID")
+	if !ok {
+		return
+	}
+
+	var req dto.CategoryAttributesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	links, err := c.attributeService.SetCategoryAttributes(categoryID, req.ToModel(categoryID))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, links)
+}
+
+// SetProductAttributes replaces a product's attribute values
+func (c *AttributeController) SetProductAttributes(ctx *gin.Context) {
+	productID, ok := idParam(ctx, "id", "Invalid product ID")
+	if !ok {
+		return
+	}
+
+	var req dto.ProductAttributesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	product, err := c.attributeService.SetProductAttributes(productID, req.Values)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, dto.ToProductResponse(product))
+}
+
+// handleError maps attribute service errors to HTTP responses
+func (c *AttributeController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrAttributeNotFound),
+		errors.Is(err, service.ErrCategoryNotFound),
+		errors.Is(err, service.ErrProductNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrDuplicateAttribute),
+		errors.Is(err, service.ErrAttributeOptionInUse):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidAttributeCode),
+		errors.Is(err, service.ErrInvalidAttributeType),
+		errors.Is(err, service.ErrEnumOptionsRequired),
+		errors.Is(err, service.ErrUnknownAttribute),
+		errors.Is(err, service.ErrInvalidAttributeValue),
+		errors.Is(err, service.ErrRequiredAttributeMissing):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
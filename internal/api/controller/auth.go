@@ -0,0 +1,332 @@
+package controller
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/Durgarao310/zneha-backend/internal/api/middleware"
+	"github.com/Durgarao310/zneha-backend/internal/dto"
+	"github.com/Durgarao310/zneha-backend/internal/service"
+	"github.com/Durgarao310/zneha-backend/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+type AuthController struct {
+	authService service.AuthService
+}
+
+func NewAuthController(authService service.AuthService) *AuthController {
+	return &AuthController{
+		authService: authService,
+	}
+}
+
+// Register handles creating a new user account
+func (c *AuthController) Register(ctx *gin.Context) {
+	var req dto.RegisterRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := c.authService.Register(req.Email, req.Password, req.Name)
+	if err != nil {
+		if errors.Is(err, service.ErrEmailTaken) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusCreated, dto.ToUserResponse(user))
+}
+
+// Login handles password login and returns a signed access token
+func (c *AuthController) Login(ctx *gin.Context) {
+	var req dto.LoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := c.authService.Login(req.Email, req.Password, clientInfo(ctx))
+	if err != nil {
+		c.handleAuthError(ctx, err)
+		return
+	}
+
+	if result.MFARequired {
+		api.SendSuccess(ctx, http.StatusOK, dto.ToMFAChallengeResponse(result.MFAToken, result.MFAExpiresAt))
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, toAuthResponse(result))
+}
+
+// RequestPhoneOTP texts a one-time login code to a mobile number
+func (c *AuthController) RequestPhoneOTP(ctx *gin.Context) {
+	var req dto.PhoneOTPRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expiresAt, err := c.authService.RequestPhoneOTP(req.Phone, clientInfo(ctx))
+	if err != nil {
+		c.handleAuthError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, dto.PhoneOTPSentResponse{
+		Message:   "Code sent",
+		ExpiresAt: expiresAt.Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
+
+// VerifyPhoneOTP logs in with an SMS code, creating the account on first use
+func (c *AuthController) VerifyPhoneOTP(ctx *gin.Context) {
+	var req dto.PhoneOTPVerifyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := c.authService.VerifyPhoneOTP(req.Phone, req.Code, clientInfo(ctx))
+	if err != nil {
+		c.handleAuthError(ctx, err)
+		return
+	}
+
+	if result.MFARequired {
+		api.SendSuccess(ctx, http.StatusOK, dto.ToMFAChallengeResponse(result.MFAToken, result.MFAExpiresAt))
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, toAuthResponse(result))
+}
+
+// VerifyMFA completes a two-factor login and returns the token pair
+func (c *AuthController) VerifyMFA(ctx *gin.Context) {
+	var req dto.MFAVerifyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := c.authService.VerifyMFA(req.MFAToken, req.Code, clientInfo(ctx))
+	if err != nil {
+		c.handleAuthError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, toAuthResponse(result))
+}
+
+// Refresh exchanges a refresh token for a new token pair
+func (c *AuthController) Refresh(ctx *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := c.authService.Refresh(req.RefreshToken, clientInfo(ctx))
+	if err != nil {
+		c.handleAuthError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, toAuthResponse(result))
+}
+
+// Logout revokes the session the refresh token belongs to
+func (c *AuthController) Logout(ctx *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.authService.Logout(req.RefreshToken); err != nil {
+		c.handleAuthError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// LogoutAll revokes every session of the authenticated user
+func (c *AuthController) LogoutAll(ctx *gin.Context) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := c.authService.LogoutAll(userID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, gin.H{"message": "Logged out from all sessions"})
+}
+
+// ForgotPassword emails a password reset link; it always succeeds to avoid leaking accounts
+func (c *AuthController) ForgotPassword(ctx *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.authService.RequestPasswordReset(req.Email); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, gin.H{"message": "If the email is registered, a reset link has been sent"})
+}
+
+// ResetPassword sets a new password using a reset token
+func (c *AuthController) ResetPassword(ctx *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.authService.ResetPassword(req.Token, req.Password); err != nil {
+		c.handleAuthError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, gin.H{"message": "Password has been reset"})
+}
+
+// VerifyEmail confirms the email address a verification token was sent to
+func (c *AuthController) VerifyEmail(ctx *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.authService.VerifyEmail(req.Token); err != nil {
+		c.handleAuthError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, gin.H{"message": "Email address verified"})
+}
+
+// ResendVerification sends a new verification email to the authenticated user
+func (c *AuthController) ResendVerification(ctx *gin.Context) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := c.authService.SendVerificationEmail(userID); err != nil {
+		c.handleAuthError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, gin.H{"message": "Verification email sent"})
+}
+
+// Me returns the currently authenticated user
+func (c *AuthController) Me(ctx *gin.Context) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	user, err := c.authService.GetUserByID(userID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, dto.ToUserResponse(user))
+}
+
+// RevokeUserSessions lets an administrator revoke every session of a user
+func (c *AuthController) RevokeUserSessions(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := c.authService.LogoutAll(userID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, gin.H{"message": "User sessions revoked"})
+}
+
+// RevokeSession lets an administrator revoke a single session by its ID
+func (c *AuthController) RevokeSession(ctx *gin.Context) {
+	if err := c.authService.RevokeSession(ctx.Param("sessionId")); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, gin.H{"message": "Session revoked"})
+}
+
+// handleAuthError maps authentication errors to HTTP responses
+func (c *AuthController) handleAuthError(ctx *gin.Context, err error) {
+	var throttleErr *service.ThrottleError
+	if errors.As(err, &throttleErr) {
+		retryAfter := int(math.Ceil(throttleErr.RetryAfter.Seconds()))
+		ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+		ctx.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error(), "retryAfter": retryAfter})
+		return
+	}
+
+	switch {
+	case errors.Is(err, service.ErrInvalidCredentials),
+		errors.Is(err, service.ErrInvalidRefreshToken),
+		errors.Is(err, service.ErrRefreshTokenReused),
+		errors.Is(err, service.ErrInvalidTwoFactorCode),
+		errors.Is(err, service.ErrInvalidOTP):
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrUserInactive),
+		errors.Is(err, service.ErrEmailNotVerified):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidUserToken),
+		errors.Is(err, service.ErrInvalidPhone),
+		errors.Is(err, service.ErrNoEmailAddress):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrEmailAlreadyVerified),
+		errors.Is(err, service.ErrTwoFactorAlreadyEnabled):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTwoFactorNotEnabled),
+		errors.Is(err, service.ErrTwoFactorNotStarted):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrUserNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// clientInfo captures the caller's IP and user agent for session records
+func clientInfo(ctx *gin.Context) service.ClientInfo {
+	return service.ClientInfo{
+		IPAddress: ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+	}
+}
+
+// toAuthResponse converts a service auth result to the response DTO
+func toAuthResponse(result *service.AuthResult) dto.AuthResponse {
+	return dto.ToAuthResponse(result.User, result.AccessToken, result.ExpiresAt, result.RefreshToken, result.RefreshExpiresAt)
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Durgarao310/zneha-backend/internal/dto"
+	"github.com/Durgarao310/zneha-backend/internal/service"
+	"github.com/Durgarao310/zneha-backend/pkg/api"
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+type BrandController struct {
+	brandService service.BrandService
+}
+
+func NewBrandController(brandService service.BrandService) *BrandController {
+	return &BrandController{
+		brandService: brandService,
+	}
+}
+
+func (c *BrandController) ListBrands(ctx *gin.Context) {
+	params := pagination.GetPaginationParams(ctx)
+
+	brands, totalItems, err := c.brandService.ListBrands(params.Page, params.Limit)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendPaginatedSuccess(ctx, http.StatusOK, brands, params.Page, params.Limit, int(totalItems))
+}
+
+func (c *BrandController) GetBrand(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", "Invalid brand ID")
+	if !ok {
+		return
+	}
+
+	brand, err := c.brandService.GetBrand(id)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, brand)
+}
+
+// GetBrandBySlug handles retrieving a brand by its current or an earlier slug
+func (c *BrandController) GetBrandBySlug(ctx *gin.Context) {
+	brand, moved, err := c.brandService.GetBrandBySlug(ctx.Param("slug"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, dto.BrandSlugResponse{Brand: *brand, Moved: moved})
+}
+
+// GetBrandProducts pages through the products sold under a brand
+func (c *BrandController) GetBrandProducts(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", "Invalid brand ID")
+	if !ok {
+		return
+	}
+
+	params := pagination.GetPaginationParams(ctx)
+
+	products, totalItems, err := c.brandService.GetBrandProducts(id, params.Page, params.Limit)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendPaginatedSuccess(ctx, http.StatusOK, dto.ToProductResponseList(products), params.Page, params.Limit, int(totalItems))
+}
+
+func (c *BrandController) CreateBrand(ctx *gin.Context) {
+	var req dto.BrandRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	brand := req.ToModel(0)
+	if err := c.brandService.CreateBrand(brand); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusCreated, brand)
+}
+
+func (c *BrandController) UpdateBrand(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", "Invalid brand ID")
+	if !ok {
+		return
+	}
+
+	var req dto.BrandRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	brand := req.ToModel(id)
+	if err := c.brandService.UpdateBrand(brand); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, brand)
+}
+
+func (c *BrandController) DeleteBrand(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", "Invalid brand ID")
+	if !ok {
+		return
+	}
+
+	if err := c.brandService.DeleteBrand(id); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusNoContent, struct{}{})
+}
+
+// handleError maps brand service errors to HTTP responses
+func (c *BrandController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrBrandNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrLogoMediaNotFound),
+		errors.Is(err, service.ErrInvalidSlug):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrSlugTaken):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
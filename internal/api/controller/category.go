@@ -1,9 +1,11 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/Durgarao310/zneha-backend/internal/dto"
 	"github.com/Durgarao310/zneha-backend/internal/model"
 	"github.com/Durgarao310/zneha-backend/internal/service"
 	"github.com/Durgarao310/zneha-backend/pkg/api"
@@ -13,23 +15,26 @@ import (
 
 type CategoryController struct {
 	categoryService service.CategoryService
+	productService  service.ProductService
 }
 
-func NewCategoryController(categoryService service.CategoryService) *CategoryController {
+func NewCategoryController(categoryService service.CategoryService, productService service.ProductService) *CategoryController {
 	return &CategoryController{
 		categoryService: categoryService,
+		productService:  productService,
 	}
 }
 
 func (c *CategoryController) CreateCategory(ctx *gin.Context) {
-	var category model.Category
-	if err := ctx.ShouldBindJSON(&category); err != nil {
+	var req dto.CategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if err := c.categoryService.CreateCategory(&category); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	category := req.ToModel(0)
+	if err := c.categoryService.CreateCategory(category); err != nil {
+		c.handleError(ctx, err)
 		return
 	}
 
@@ -53,6 +58,17 @@ func (c *CategoryController) GetCategory(ctx *gin.Context) {
 	api.SendSuccess(ctx, http.StatusOK, category)
 }
 
+// GetCategoryBySlug finds a category by its current or an earlier slug
+func (c *CategoryController) GetCategoryBySlug(ctx *gin.Context) {
+	category, moved, err := c.categoryService.GetCategoryBySlug(ctx.Param("slug"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, dto.CategorySlugResponse{Category: *category, Moved: moved})
+}
+
 func (c *CategoryController) GetAllCategories(ctx *gin.Context) {
 	// Use common pagination utility
 	params := pagination.GetPaginationParams(ctx)
@@ -97,7 +113,115 @@ func (c *CategoryController) GetSubcategories(ctx *gin.Context) {
 	api.SendPaginatedSuccess(ctx, http.StatusOK, subcategories, params.Page, params.Limit, int(totalItems))
 }
 
-func (c *CategoryController) UpdateCategory(ctx *gin.Context) {
+// GetCategoryProducts lists a category's products. Pass ?includeDescendants=true
+// to include products assigned to any subcategory.
+func (c *CategoryController) GetCategoryProducts(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	if _, err := c.categoryService.GetCategoryByID(id); err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+
+	includeDescendants, _ := strconv.ParseBool(ctx.Query("includeDescendants"))
+
+	// Use common pagination utility
+	params := pagination.GetPaginationParams(ctx)
+
+	products, totalItems, err := c.productService.GetByCategory(id, includeDescendants, params.Page, params.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendPaginatedSuccess(ctx, http.StatusOK, dto.ToProductResponseList(products), params.Page, params.Limit, int(totalItems))
+}
+
+// GetTree returns the nested category hierarchy for navigation menus. Pass
+// ?rootId= for a subtree and ?maxDepth=N to limit the number of levels.
+func (c *CategoryController) GetTree(ctx *gin.Context) {
+	var rootID *uint64
+	if raw := ctx.Query("rootId"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid root category ID"})
+			return
+		}
+		rootID = &id
+	}
+
+	maxDepth := 0
+	if raw := ctx.Query("maxDepth"); raw != "" {
+		var err error
+		maxDepth, err = strconv.Atoi(raw)
+		if err != nil || maxDepth < 1 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "maxDepth must be a positive integer"})
+			return
+		}
+	}
+
+	rows, err := c.categoryService.GetTree(rootID, maxDepth)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, dto.ToCategoryTree(rows))
+}
+
+// GetAncestors returns the breadcrumb trail from the root down to the category's parent
+func (c *CategoryController) GetAncestors(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	ancestors, err := c.categoryService.GetAncestors(id)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, ancestors)
+}
+
+// GetDescendants returns every category below the given one. Pass ?maxDepth=N
+// to stop N levels down.
+func (c *CategoryController) GetDescendants(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	maxDepth := 0
+	if raw := ctx.Query("maxDepth"); raw != "" {
+		maxDepth, err = strconv.Atoi(raw)
+		if err != nil || maxDepth < 1 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "maxDepth must be a positive integer"})
+			return
+		}
+	}
+
+	descendants, err := c.categoryService.GetDescendants(id, maxDepth)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, descendants)
+}
+
+// MoveCategory moves a category and its whole subtree under a new parent
+func (c *CategoryController) MoveCategory(ctx *gin.Context) {
 	idParam := ctx.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 64)
 	if err != nil {
@@ -105,18 +229,71 @@ func (c *CategoryController) UpdateCategory(ctx *gin.Context) {
 		return
 	}
 
-	var category model.Category
-	if err := ctx.ShouldBindJSON(&category); err != nil {
+	var req dto.CategoryMoveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	category.ID = id
-	if err := c.categoryService.UpdateCategory(&category); err != nil {
+	category, err := c.categoryService.MoveCategory(id, req.ParentID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, category)
+}
+
+// ReorderCategories rewrites the order of a set of sibling categories
+func (c *CategoryController) ReorderCategories(ctx *gin.Context) {
+	var req dto.CategoryReorderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.categoryService.ReorderCategories(req.ParentID, req.IDs); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	var (
+		categories []model.Category
+		err        error
+	)
+	if req.ParentID == nil {
+		categories, err = c.categoryService.GetMainCategories()
+	} else {
+		categories, err = c.categoryService.GetSubCategories(*req.ParentID)
+	}
+	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	api.SendSuccess(ctx, http.StatusOK, categories)
+}
+
+func (c *CategoryController) UpdateCategory(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	var req dto.CategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category := req.ToModel(id)
+	if err := c.categoryService.UpdateCategory(category); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
 	api.SendSuccess(ctx, http.StatusOK, category)
 }
 
@@ -135,3 +312,20 @@ func (c *CategoryController) DeleteCategory(ctx *gin.Context) {
 
 	api.SendSuccess(ctx, http.StatusNoContent, struct{}{})
 }
+
+// handleError maps category service errors to HTTP responses
+func (c *CategoryController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCategoryNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+	case errors.Is(err, service.ErrParentCategoryNotFound),
+		errors.Is(err, service.ErrInvalidCategoryOrder),
+		errors.Is(err, service.ErrInvalidSlug):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCategoryCycle),
+		errors.Is(err, service.ErrSlugTaken):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
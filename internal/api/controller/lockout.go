@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Durgarao310/zneha-backend/internal/api/middleware"
+	"github.com/Durgarao310/zneha-backend/internal/service"
+	"github.com/Durgarao310/zneha-backend/pkg/api"
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+type LockoutController struct {
+	loginThrottle service.LoginThrottleService
+}
+
+func NewLockoutController(loginThrottle service.LoginThrottleService) *LockoutController {
+	return &LockoutController{
+		loginThrottle: loginThrottle,
+	}
+}
+
+// ListLockouts returns recorded lockouts, newest first. Pass ?active=true for current ones only.
+func (c *LockoutController) ListLockouts(ctx *gin.Context) {
+	params := pagination.GetPaginationParams(ctx)
+	activeOnly, _ := strconv.ParseBool(ctx.Query("active"))
+
+	events, totalItems, err := c.loginThrottle.ListLockouts(activeOnly, params.Page, params.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendPaginatedSuccess(ctx, http.StatusOK, events, params.Page, params.Limit, int(totalItems))
+}
+
+// UnlockUser lifts a lockout on a user's account before it expires
+func (c *LockoutController) UnlockUser(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(ctx)
+	if err := c.loginThrottle.UnlockUser(userID, adminID); err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, gin.H{"message": "User unlocked"})
+}
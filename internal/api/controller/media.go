@@ -63,15 +63,19 @@ func (c *MediaController) GetMediaByProduct(ctx *gin.Context) {
 
 	// Use common pagination utility
 	params := pagination.GetPaginationParams(ctx)
+	spec, ok := querySpec(ctx)
+	if !ok {
+		return
+	}
 
 	// Use efficient database-level pagination
-	media, totalItems, err := c.mediaService.GetMediaByProductIDWithPagination(productID, params.Page, params.Limit)
+	media, info, err := c.mediaService.GetMediaByProductIDWithPagination(productID, spec, params)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		listError(ctx, err)
 		return
 	}
 
-	api.SendPaginatedSuccess(ctx, http.StatusOK, media, params.Page, params.Limit, int(totalItems))
+	api.SendPageSuccess(ctx, http.StatusOK, media, params, info)
 }
 
 func (c *MediaController) GetMediaByVariant(ctx *gin.Context) {
@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Durgarao310/zneha-backend/internal/dto"
+	"github.com/Durgarao310/zneha-backend/internal/service"
+	"github.com/Durgarao310/zneha-backend/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+type MetafieldController struct {
+	metafieldService service.MetafieldService
+}
+
+func NewMetafieldController(metafieldService service.MetafieldService) *MetafieldController {
+	return &MetafieldController{
+		metafieldService: metafieldService,
+	}
+}
+
+// ListDefinitions returns metafield definitions, optionally for one ?ownerType=
+func (c *MetafieldController) ListDefinitions(ctx *gin.Context) {
+	definitions, err := c.metafieldService.ListDefinitions(ctx.Query("ownerType"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, definitions)
+}
+
+func (c *MetafieldController) GetDefinition(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", "Invalid definition ID")
+	if !ok {
+		return
+	}
+
+	definition, err := c.metafieldService.GetDefinition(id)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, definition)
+}
+
+func (c *MetafieldController) CreateDefinition(ctx *gin.Context) {
+	var req dto.MetafieldDefinitionCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	definition := req.ToModel()
+	if err := c.metafieldService.CreateDefinition(definition); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusCreated, definition)
+}
+
+func (c *MetafieldController) UpdateDefinition(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", "Invalid definition ID")
+	if !ok {
+		return
+	}
+
+	var req dto.MetafieldDefinitionUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	definition := req.ToModel(id)
+	if err := c.metafieldService.UpdateDefinition(definition); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, definition)
+}
+
+func (c *MetafieldController) DeleteDefinition(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", "Invalid definition ID")
+	if !ok {
+		return
+	}
+
+	if err := c.metafieldService.DeleteDefinition(id); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusNoContent, struct{}{})
+}
+
+// ListPublic returns the public metafields of a resource
+func (c *MetafieldController) ListPublic(ctx *gin.Context) {
+	c.list(ctx, false)
+}
+
+// ListAll returns every metafield of a resource, admin-only ones included
+func (c *MetafieldController) ListAll(ctx *gin.Context) {
+	c.list(ctx, true)
+}
+
+func (c *MetafieldController) list(ctx *gin.Context, includeAdmin bool) {
+	ownerID, ok := idParam(ctx, "ownerId", "Invalid owner ID")
+	if !ok {
+		return
+	}
+
+	metafields, err := c.metafieldService.ListMetafields(ctx.Param("ownerType"), ownerID, includeAdmin)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, dto.ToMetafieldResponseList(metafields))
+}
+
+// SetMetafield creates or replaces the value of namespace.key on a resource
+func (c *MetafieldController) SetMetafield(ctx *gin.Context) {
+	ownerID, ok := idParam(ctx, "ownerId", "Invalid owner ID")
+	if !ok {
+		return
+	}
+
+	var req dto.MetafieldValueRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	metafield, err := c.metafieldService.SetMetafield(ctx.Param("ownerType"), ownerID, ctx.Param("namespace"), ctx.Param("key"), req.Value)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, dto.ToMetafieldResponse(metafield))
+}
+
+func (c *MetafieldController) DeleteMetafield(ctx *gin.Context) {
+	ownerID, ok := idParam(ctx, "ownerId", "Invalid owner ID")
+	if !ok {
+		return
+	}
+
+	err := c.metafieldService.DeleteMetafield(ctx.Param("ownerType"), ownerID, ctx.Param("namespace"), ctx.Param("key"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusNoContent, struct{}{})
+}
+
+// handleError maps metafield service errors to HTTP responses
+func (c *MetafieldController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrMetafieldDefinitionNotFound),
+		errors.Is(err, service.ErrMetafieldNotFound),
+		errors.Is(err, service.ErrMetafieldOwnerNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrDuplicateMetafieldDefinition):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidMetafieldOwner),
+		errors.Is(err, service.ErrInvalidMetafieldKey),
+		errors.Is(err, service.ErrInvalidMetafieldType),
+		errors.Is(err, service.ErrInvalidMetafieldVisibility),
+		errors.Is(err, service.ErrInvalidMetafieldValue):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,284 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Durgarao310/zneha-backend/internal/dto"
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/service"
+	"github.com/Durgarao310/zneha-backend/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+type OptionController struct {
+	optionService service.OptionService
+}
+
+func NewOptionController(optionService service.OptionService) *OptionController {
+	return &OptionController{
+		optionService: optionService,
+	}
+}
+
+// ListOptions returns a product's option types with their values
+func (c *OptionController) ListOptions(ctx *gin.Context) {
+	productID, ok := idParam(ctx, "id", "Invalid product ID")
+	if !ok {
+		return
+	}
+
+	optionTypes, err := c.optionService.ListOptions(productID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, optionTypes)
+}
+
+func (c *OptionController) CreateOption(ctx *gin.Context) {
+	productID, ok := idParam(ctx, "id", "Invalid product ID")
+	if !ok {
+		return
+	}
+
+	var req dto.OptionTypeCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	optionType := req.ToModel(productID)
+	if err := c.optionService.CreateOption(optionType); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusCreated, optionType)
+}
+
+func (c *OptionController) UpdateOption(ctx *gin.Context) {
+	productID, ok := idParam(ctx, "id", "Invalid product ID")
+	if !ok {
+		return
+	}
+	optionTypeID, ok := idParam(ctx, "optionId", "Invalid option ID")
+	if !ok {
+		return
+	}
+
+	var req dto.OptionTypeUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	optionType := &model.OptionType{ID: optionTypeID, ProductID: productID, Name: req.Name}
+	if err := c.optionService.UpdateOption(optionType); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, optionType)
+}
+
+func (c *OptionController) DeleteOption(ctx *gin.Context) {
+	productID, ok := idParam(ctx, "id", "Invalid product ID")
+	if !ok {
+		return
+	}
+	optionTypeID, ok := idParam(ctx, "optionId", "Invalid option ID")
+	if !ok {
+		return
+	}
+
+	if err := c.optionService.DeleteOption(productID, optionTypeID); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusNoContent, struct{}{})
+}
+
+func (c *OptionController) AddValue(ctx *gin.Context) {
+	productID, ok := idParam(ctx, "id", "Invalid product ID")
+	if !ok {
+		return
+	}
+	optionTypeID, ok := idParam(ctx, "optionId", "Invalid option ID")
+	if !ok {
+		return
+	}
+
+	var req dto.OptionValueRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	value := req.ToModel(optionTypeID)
+	if err := c.optionService.AddValue(productID, value); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusCreated, value)
+}
+
+func (c *OptionController) DeleteValue(ctx *gin.Context) {
+	productID, ok := idParam(ctx, "id", "Invalid product ID")
+	if !ok {
+		return
+	}
+	optionTypeID, ok := idParam(ctx, "optionId", "Invalid option ID")
+	if !ok {
+		return
+	}
+	valueID, ok := idParam(ctx, "valueId", "Invalid option value ID")
+	if !ok {
+		return
+	}
+
+	if err := c.optionService.DeleteValue(productID, optionTypeID, valueID); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusNoContent, struct{}{})
+}
+
+// ReorderOptions sets the order of a product's option types
+func (c *OptionController) ReorderOptions(ctx *gin.Context) {
+	productID, ok := idParam(ctx, "id", "Invalid product ID")
+	if !ok {
+		return
+	}
+
+	var req dto.OptionTypeReorderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	optionTypes, err := c.optionService.ReorderOptions(productID, req.IDs)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, optionTypes)
+}
+
+// ReorderValues sets the display order of an option's values
+func (c *OptionController) ReorderValues(ctx *gin.Context) {
+	productID, ok := idParam(ctx, "id", "Invalid product ID")
+	if !ok {
+		return
+	}
+	optionTypeID, ok := idParam(ctx, "optionId", "Invalid option ID")
+	if !ok {
+		return
+	}
+
+	var req dto.OptionValueReorderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	optionType, err := c.optionService.ReorderValues(productID, optionTypeID, req.IDs)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, optionType)
+}
+
+// SetVariantOptions links a variant to its option values, e.g. Red and XL
+func (c *OptionController) SetVariantOptions(ctx *gin.Context) {
+	variantID, ok := idParam(ctx, "id", "Invalid variant ID")
+	if !ok {
+		return
+	}
+
+	var req dto.VariantOptionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	variant, err := c.optionService.SetVariantOptions(variantID, req.OptionValueIDs)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, variant)
+}
+
+// GenerateVariants creates the missing variants of a product's option matrix
+func (c *OptionController) GenerateVariants(ctx *gin.Context) {
+	productID, ok := idParam(ctx, "id", "Invalid product ID")
+	if !ok {
+		return
+	}
+
+	var req dto.GenerateVariantsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, skipped, err := c.optionService.GenerateVariants(productID, service.GenerateVariantsInput{
+		SKUTemplate:   req.SKUTemplate,
+		Price:         req.Price,
+		StockQuantity: req.StockQuantity,
+	})
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusCreated, dto.GenerateVariantsResponse{Created: created, Skipped: skipped})
+}
+
+// handleError maps option service errors to HTTP responses
+func (c *OptionController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrProductNotFound),
+		errors.Is(err, service.ErrOptionTypeNotFound),
+		errors.Is(err, service.ErrOptionValueNotFound),
+		errors.Is(err, service.ErrVariantNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrDuplicateOption),
+		errors.Is(err, service.ErrOptionInUse),
+		errors.Is(err, service.ErrVariantCombinationExists),
+		errors.Is(err, service.ErrSKUTaken):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrReservedOptionName),
+		errors.Is(err, service.ErrInvalidOptionCode),
+		errors.Is(err, service.ErrInvalidOptionOrder),
+		errors.Is(err, service.ErrNoOptions),
+		errors.Is(err, service.ErrOptionWithoutValues),
+		errors.Is(err, service.ErrTooManyVariants),
+		errors.Is(err, service.ErrInvalidSKUTemplate),
+		errors.Is(err, service.ErrSKUTooLong),
+		errors.Is(err, service.ErrInvalidVariantOptions):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// idParam parses a numeric path parameter, answering 400 with message when it is not one
+func idParam(ctx *gin.Context, name, message string) (uint64, bool) {
+	id, err := strconv.ParseUint(ctx.Param(name), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return 0, false
+	}
+	return id, true
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -17,19 +18,24 @@ type ProductController interface {
 	Create(c *gin.Context)
 	GetAll(c *gin.Context)
 	GetByID(c *gin.Context)
+	GetBySlug(c *gin.Context)
+	Search(c *gin.Context)
+	GetSEO(c *gin.Context)
 	Update(c *gin.Context)
 	Delete(c *gin.Context)
 }
 
 // productController implements ProductController interface
 type productController struct {
-	service service.ProductService
+	service    service.ProductService
+	seoService service.SEOService
 }
 
 // NewProductController creates a new instance of ProductController
-func NewProductController(service service.ProductService) ProductController {
+func NewProductController(service service.ProductService, seoService service.SEOService) ProductController {
 	return &productController{
-		service: service,
+		service:    service,
+		seoService: seoService,
 	}
 }
 
@@ -37,6 +43,12 @@ func NewProductController(service service.ProductService) ProductController {
 func (c *productController) Create(ctx *gin.Context) {
 	var req dto.ProductCreateRequest
 
+	// Bind JSON request to struct
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	status := req.Status
 	if status == "" {
 		status = "active"
@@ -44,33 +56,70 @@ func (c *productController) Create(ctx *gin.Context) {
 
 	product := model.Product{
 		Name:             req.Name,
+		Slug:             req.Slug,
 		Description:      req.Description,
 		ShortDescription: req.ShortDescription,
 		Status:           status,
+		MetaTitle:        req.MetaTitle,
+		MetaDescription:  req.MetaDescription,
+		CanonicalURL:     req.CanonicalURL,
+		BrandID:          req.BrandID,
 	}
 
-	if err := c.service.Create(&product); err != nil {
+	if err := c.service.Create(&product, req.CategoryIDs, req.PrimaryCategoryID); err != nil {
+		c.handleError(ctx, err)
 		return
 	}
 
-	api.SendSuccess(ctx, http.StatusCreated, dto.ToProductResponse(&product))
+	saved, err := c.service.GetByID(product.ID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusCreated, dto.ToProductResponse(saved))
 }
 
-// GetAll handles retrieving all products with optional pagination
+// GetAll handles retrieving all products with optional pagination and ?brandId= filter
 func (c *productController) GetAll(ctx *gin.Context) {
+	var query dto.ProductListQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Use common pagination utility
 	params := pagination.GetPaginationParams(ctx)
+	spec, ok := querySpec(ctx)
+	if !ok {
+		return
+	}
+
+	input := service.ProductListInput{
+		BrandIDs:    query.BrandIDs,
+		CategoryIDs: query.CategoryIDs,
+		Status:      query.Status,
+		MinPrice:    query.MinPrice,
+		MaxPrice:    query.MaxPrice,
+		InStock:     query.InStock,
+		Attributes:  ctx.QueryMap("attr"),
+		Query:       spec,
+	}
 
 	// Use efficient database pagination
-	products, totalItems, err := c.service.GetWithPagination(params.Page, params.Limit)
+	products, info, facets, err := c.service.List(input, params)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.handleError(ctx, err)
 		return
 	}
 
 	responses := dto.ToProductResponseList(products)
 
-	api.SendPaginatedSuccess(ctx, http.StatusOK, responses, params.Page, params.Limit, int(totalItems))
+	var meta any // stays nil on later cursor pages, which carry no facets
+	if facets != nil {
+		meta = facets
+	}
+	api.SendFacetedSuccess(ctx, http.StatusOK, responses, params, info, meta)
 }
 
 // GetByID handles retrieving a product by its ID
@@ -89,6 +138,51 @@ func (c *productController) GetByID(ctx *gin.Context) {
 	api.SendSuccess(ctx, http.StatusOK, dto.ToProductResponse(product))
 }
 
+// GetBySlug handles retrieving a product by its current or an earlier slug
+func (c *productController) GetBySlug(ctx *gin.Context) {
+	product, moved, err := c.service.GetBySlug(ctx.Param("slug"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, dto.ProductSlugResponse{
+		ProductResponse: dto.ToProductResponse(product),
+		Moved:           moved,
+	})
+}
+
+// Search handles full-text product search with ?q=, most relevant first
+func (c *productController) Search(ctx *gin.Context) {
+	params := pagination.GetPaginationParams(ctx)
+
+	hits, totalItems, err := c.service.Search(ctx.Query("q"), params.Page, params.Limit)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendPaginatedSuccess(ctx, http.StatusOK, dto.ToProductSearchResults(hits), params.Page, params.Limit, int(totalItems))
+}
+
+// GetSEO handles rendering a product's JSON-LD and Open Graph tags
+func (c *productController) GetSEO(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	result, err := c.seoService.GetProductSEO(id)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, result)
+}
+
 // Update handles updating an existing product
 func (c *productController) Update(ctx *gin.Context) {
 	idStr := ctx.Param("id")
@@ -101,6 +195,7 @@ func (c *productController) Update(ctx *gin.Context) {
 
 	// Bind JSON request to struct
 	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -112,16 +207,28 @@ func (c *productController) Update(ctx *gin.Context) {
 	product := model.Product{
 		ID:               id,
 		Name:             req.Name,
+		Slug:             req.Slug,
 		Description:      req.Description,
 		ShortDescription: req.ShortDescription,
 		Status:           status,
+		MetaTitle:        req.MetaTitle,
+		MetaDescription:  req.MetaDescription,
+		CanonicalURL:     req.CanonicalURL,
+		BrandID:          req.BrandID,
 	}
 
-	if err := c.service.Update(&product); err != nil {
+	if err := c.service.Update(&product, req.CategoryIDs, req.PrimaryCategoryID); err != nil {
+		c.handleError(ctx, err)
 		return
 	}
 
-	api.SendSuccess(ctx, http.StatusOK, dto.ToProductResponse(&product))
+	saved, err := c.service.GetByID(product.ID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, dto.ToProductResponse(saved))
 }
 
 // Delete handles deleting a product by its ID
@@ -138,3 +245,26 @@ func (c *productController) Delete(ctx *gin.Context) {
 
 	api.SendSuccess(ctx, http.StatusNoContent, struct{}{})
 }
+
+// handleError maps product service errors to HTTP responses
+func (c *productController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrProductNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrUnknownCategory),
+		errors.Is(err, service.ErrPrimaryCategoryNotAssigned),
+		errors.Is(err, service.ErrUnknownBrand),
+		errors.Is(err, service.ErrInvalidSearchQuery),
+		errors.Is(err, service.ErrInvalidPriceRange),
+		errors.Is(err, service.ErrUnknownAttribute),
+		errors.Is(err, service.ErrUnknownAttributeFilter),
+		errors.Is(err, service.ErrInvalidAttributeValue),
+		errors.Is(err, pagination.ErrInvalidQuery),
+		errors.Is(err, service.ErrInvalidSlug):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrSlugTaken):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+// querySpec parses the sort and filter parameters, answering 400 when they are malformed
+func querySpec(ctx *gin.Context) (pagination.QuerySpec, bool) {
+	spec, err := pagination.GetQuerySpec(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return spec, false
+	}
+	return spec, true
+}
+
+// listError answers a failed list query, 400 when a sort or filter field is not allowed
+func listError(ctx *gin.Context, err error) {
+	if errors.Is(err, pagination.ErrInvalidQuery) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Durgarao310/zneha-backend/internal/api/middleware"
+	"github.com/Durgarao310/zneha-backend/internal/dto"
+	"github.com/Durgarao310/zneha-backend/internal/service"
+	"github.com/Durgarao310/zneha-backend/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+type RoleController struct {
+	rbacService service.RBACService
+}
+
+func NewRoleController(rbacService service.RBACService) *RoleController {
+	return &RoleController{
+		rbacService: rbacService,
+	}
+}
+
+func (c *RoleController) ListPermissions(ctx *gin.Context) {
+	permissions, err := c.rbacService.ListPermissions()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, permissions)
+}
+
+func (c *RoleController) ListRoles(ctx *gin.Context) {
+	roles, err := c.rbacService.ListRoles()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, roles)
+}
+
+func (c *RoleController) GetRole(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		return
+	}
+
+	role, err := c.rbacService.GetRole(id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, role)
+}
+
+func (c *RoleController) CreateRole(ctx *gin.Context) {
+	var req dto.RoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := c.rbacService.CreateRole(req.Name, req.Description, req.RequireMFA, req.Permissions, middleware.GetPermissions(ctx))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusCreated, role)
+}
+
+func (c *RoleController) UpdateRole(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		return
+	}
+
+	var req dto.RoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := c.rbacService.UpdateRole(id, req.Name, req.Description, req.RequireMFA, req.Permissions, middleware.GetPermissions(ctx))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, role)
+}
+
+func (c *RoleController) DeleteRole(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		return
+	}
+
+	if err := c.rbacService.DeleteRole(id); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusNoContent, struct{}{})
+}
+
+func (c *RoleController) GetUserRoles(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	roles, err := c.rbacService.GetUserRoles(userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, roles)
+}
+
+func (c *RoleController) AssignRole(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req dto.AssignRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.rbacService.AssignRole(userID, req.RoleID, middleware.GetPermissions(ctx)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, gin.H{"message": "Role assigned successfully"})
+}
+
+func (c *RoleController) RevokeRole(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	roleID, err := strconv.ParseUint(ctx.Param("roleId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		return
+	}
+
+	if err := c.rbacService.RevokeRole(userID, roleID); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusNoContent, struct{}{})
+}
+
+// handleError maps RBAC service errors to HTTP responses
+func (c *RoleController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrUnknownPermission):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrPermissionNotHeld):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrRoleNotFound), errors.Is(err, service.ErrUserNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Durgarao310/zneha-backend/internal/service"
+	"github.com/Durgarao310/zneha-backend/pkg/api"
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+// Suggestions per type: products, categories and brands
+const (
+	defaultSuggestLimit = 5
+	maxSuggestLimit     = 10
+)
+
+type SearchController struct {
+	searchService service.SearchService
+}
+
+func NewSearchController(searchService service.SearchService) *SearchController {
+	return &SearchController{
+		searchService: searchService,
+	}
+}
+
+// Suggest handles search box autocomplete: GET /search/suggest?q=&limit=
+func (c *SearchController) Suggest(ctx *gin.Context) {
+	params := pagination.GetPaginationParamsWithCustomLimits(ctx, defaultSuggestLimit, maxSuggestLimit)
+
+	suggestions, err := c.searchService.Suggest(ctx.Query("q"), params.Limit)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, suggestions)
+}
+
+func (c *SearchController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidSuggestQuery):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
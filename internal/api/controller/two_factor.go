@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Durgarao310/zneha-backend/internal/api/middleware"
+	"github.com/Durgarao310/zneha-backend/internal/dto"
+	"github.com/Durgarao310/zneha-backend/internal/service"
+	"github.com/Durgarao310/zneha-backend/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorController struct {
+	twoFactorService service.TwoFactorService
+	authController   *AuthController
+}
+
+func NewTwoFactorController(twoFactorService service.TwoFactorService, authController *AuthController) *TwoFactorController {
+	return &TwoFactorController{
+		twoFactorService: twoFactorService,
+		authController:   authController,
+	}
+}
+
+// Enroll starts TOTP enrollment and returns the secret and provisioning URI
+func (c *TwoFactorController) Enroll(ctx *gin.Context) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	enrollment, err := c.twoFactorService.BeginEnrollment(userID)
+	if err != nil {
+		c.authController.handleAuthError(ctx, err)
+		return
+	}
+
+	api.SendSuccess(ctx, http.StatusOK, dto.TOTPEnrollmentResponse{
+		Secret:     enrollment.Secret,
+		OtpauthURL: enrollment.ProvisioningURI,
+	})
+}
+
+// Confirm enables TOTP once the user proves their app generates valid codes
+func (c *TwoFactorController) Confirm(ctx *gin.Context) {
+	c.withCode(ctx, func(userID uint64, code string) {
+		codes, err := c.twoFactorService.ConfirmEnrollment(userID, code)
+		if err != nil {
+			c.authController.handleAuthError(ctx, err)
+			return
+		}
+		api.SendSuccess(ctx, http.StatusOK, dto.RecoveryCodesResponse{RecoveryCodes: codes})
+	})
+}
+
+// Disable turns off two-factor authentication
+func (c *TwoFactorController) Disable(ctx *gin.Context) {
+	c.withCode(ctx, func(userID uint64, code string) {
+		if err := c.twoFactorService.Disable(userID, code); err != nil {
+			c.authController.handleAuthError(ctx, err)
+			return
+		}
+		api.SendSuccess(ctx, http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+	})
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes
+func (c *TwoFactorController) RegenerateRecoveryCodes(ctx *gin.Context) {
+	c.withCode(ctx, func(userID uint64, code string) {
+		codes, err := c.twoFactorService.RegenerateRecoveryCodes(userID, code)
+		if err != nil {
+			c.authController.handleAuthError(ctx, err)
+			return
+		}
+		api.SendSuccess(ctx, http.StatusOK, dto.RecoveryCodesResponse{RecoveryCodes: codes})
+	})
+}
+
+// withCode resolves the authenticated user and binds the code payload
+func (c *TwoFactorController) withCode(ctx *gin.Context, handle func(userID uint64, code string)) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req dto.TwoFactorCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	handle(userID, req.Code)
+}
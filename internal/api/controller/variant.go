@@ -75,15 +75,19 @@ func (c *VariantController) GetVariantsByProduct(ctx *gin.Context) {
 
 	// Use common pagination utility
 	params := pagination.GetPaginationParams(ctx)
+	spec, ok := querySpec(ctx)
+	if !ok {
+		return
+	}
 
 	// Use efficient database-level pagination
-	variants, totalItems, err := c.variantService.GetVariantsByProductIDWithPagination(productID, params.Page, params.Limit)
+	variants, info, err := c.variantService.GetVariantsByProductIDWithPagination(productID, spec, params)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		listError(ctx, err)
 		return
 	}
 
-	api.SendPaginatedSuccess(ctx, http.StatusOK, variants, params.Page, params.Limit, int(totalItems))
+	api.SendPageSuccess(ctx, http.StatusOK, variants, params, info)
 }
 
 func (c *VariantController) GetActiveVariantsByProduct(ctx *gin.Context) {
@@ -96,15 +100,19 @@ func (c *VariantController) GetActiveVariantsByProduct(ctx *gin.Context) {
 
 	// Use common pagination utility
 	params := pagination.GetPaginationParams(ctx)
+	spec, ok := querySpec(ctx)
+	if !ok {
+		return
+	}
 
 	// Use efficient database-level pagination
-	variants, totalItems, err := c.variantService.GetActiveVariantsByProductIDWithPagination(productID, params.Page, params.Limit)
+	variants, info, err := c.variantService.GetActiveVariantsByProductIDWithPagination(productID, spec, params)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		listError(ctx, err)
 		return
 	}
 
-	api.SendPaginatedSuccess(ctx, http.StatusOK, variants, params.Page, params.Limit, int(totalItems))
+	api.SendPageSuccess(ctx, http.StatusOK, variants, params, info)
 }
 
 func (c *VariantController) UpdateVariant(ctx *gin.Context) {
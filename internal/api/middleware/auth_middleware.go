@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Durgarao310/zneha-backend/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// Context keys set by the authentication middleware
+const (
+	ContextUserIDKey      = "userID"
+	ContextEmailKey       = "userEmail"
+	ContextSessionIDKey   = "sessionID"
+	ContextMFAKey         = "mfaVerified"
+	ContextPermissionsKey = "permissions"
+	ContextAPIKeyIDKey    = "apiKeyID"
+)
+
+// APIKeyHeader carries machine-to-machine API keys
+const APIKeyHeader = "X-API-Key"
+
+// AuthMiddleware authenticates requests and enforces permissions
+type AuthMiddleware struct {
+	authService   service.AuthService
+	rbacService   service.RBACService
+	apiKeyService service.APIKeyService
+}
+
+// NewAuthMiddleware creates a new authentication middleware
+func NewAuthMiddleware(authService service.AuthService, rbacService service.RBACService, apiKeyService service.APIKeyService) *AuthMiddleware {
+	return &AuthMiddleware{
+		authService:   authService,
+		rbacService:   rbacService,
+		apiKeyService: apiKeyService,
+	}
+}
+
+// RequireAuth rejects requests without a valid access token or API key
+func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if rawKey := c.GetHeader(APIKeyHeader); rawKey != "" {
+			m.authenticateAPIKey(c, rawKey)
+			return
+		}
+
+		token := bearerToken(c)
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing bearer token"})
+			return
+		}
+
+		claims, err := m.authService.ValidateAccessToken(token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		// Reject access tokens whose session was logged out or revoked
+		revoked, err := m.authService.IsSessionRevoked(claims.SessionID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify session"})
+			return
+		}
+		if revoked {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+			return
+		}
+
+		c.Set(ContextUserIDKey, claims.UserID)
+		c.Set(ContextEmailKey, claims.Email)
+		c.Set(ContextSessionIDKey, claims.SessionID)
+		c.Set(ContextMFAKey, claims.MFA)
+		c.Next()
+	}
+}
+
+// authenticateAPIKey authenticates a machine client; the key's scopes become its permissions
+func (m *AuthMiddleware) authenticateAPIKey(c *gin.Context, rawKey string) {
+	key, err := m.apiKeyService.Authenticate(rawKey)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+		return
+	}
+
+	granted := make(map[string]bool, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		granted[scope] = true
+	}
+	c.Set(ContextAPIKeyIDKey, key.ID)
+	c.Set(ContextPermissionsKey, granted)
+	c.Next()
+}
+
+// RequirePermission rejects callers lacking any of the given permissions.
+// It must run after RequireAuth.
+func (m *AuthMiddleware) RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		granted, ok := m.permissions(c)
+		if !ok {
+			return
+		}
+
+		for _, permission := range permissions {
+			if !granted[permission] {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Missing required permission: " + permission})
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// permissions returns the caller's permission set, loading it once per request.
+// It aborts the request and returns false when no permissions can be resolved.
+func (m *AuthMiddleware) permissions(c *gin.Context) (map[string]bool, bool) {
+	if value, exists := c.Get(ContextPermissionsKey); exists {
+		if granted, ok := value.(map[string]bool); ok {
+			return granted, true
+		}
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return nil, false
+	}
+
+	access, err := m.rbacService.GetUserAccess(userID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to load permissions"})
+		return nil, false
+	}
+
+	// Roles flagged RequireMFA only grant their permissions to sessions opened with a second factor
+	if access.RequireMFA && !c.GetBool(ContextMFAKey) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Two-factor authentication is required for this account"})
+		return nil, false
+	}
+
+	granted := make(map[string]bool, len(access.Permissions))
+	for _, name := range access.Permissions {
+		granted[name] = true
+	}
+	c.Set(ContextPermissionsKey, granted)
+	return granted, true
+}
+
+// GetUserID returns the authenticated user ID from the context
+func GetUserID(c *gin.Context) (uint64, bool) {
+	value, exists := c.Get(ContextUserIDKey)
+	if !exists {
+		return 0, false
+	}
+	id, ok := value.(uint64)
+	return id, ok
+}
+
+// GetPermissions returns the caller's permission set as resolved by
+// RequirePermission, or nil when it has not run
+func GetPermissions(c *gin.Context) map[string]bool {
+	value, _ := c.Get(ContextPermissionsKey)
+	granted, _ := value.(map[string]bool)
+	return granted
+}
+
+// bearerToken extracts the token from the Authorization header
+func bearerToken(c *gin.Context) string {
+	header := c.GetHeader("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(header[7:])
+}
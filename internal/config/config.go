@@ -14,6 +14,10 @@ type Config struct {
 	Database DatabaseConfig `json:"database"`
 	JWT      JWTConfig      `json:"jwt"`
 	App      AppConfig      `json:"app"`
+	Auth     AuthConfig     `json:"auth"`
+	Mail     MailConfig     `json:"mail"`
+	SMS      SMSConfig      `json:"sms"`
+	SEO      SEOConfig      `json:"seo"`
 }
 
 // ServerConfig holds server-related configuration
@@ -36,15 +40,56 @@ type DatabaseConfig struct {
 
 // JWTConfig holds JWT-related configuration
 type JWTConfig struct {
-	Secret     string `json:"secret"`
-	ExpiryHour int    `json:"expiry_hour"`
+	Secret            string `json:"secret"`
+	ExpiryHour        int    `json:"expiry_hour"`
+	RefreshExpiryHour int    `json:"refresh_expiry_hour"` // lifetime of refresh tokens
 }
 
 // AppConfig holds general application configuration
 type AppConfig struct {
-	Name    string `json:"name"`
-	Version string `json:"version"`
-	Debug   bool   `json:"debug"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Debug       bool   `json:"debug"`
+	FrontendURL string `json:"frontend_url"` // base URL used in links sent to users
+}
+
+// AuthConfig holds account and login policy configuration
+type AuthConfig struct {
+	RequireEmailVerification  bool `json:"require_email_verification"`
+	PasswordResetTTLMinutes   int  `json:"password_reset_ttl_minutes"`
+	EmailVerificationTTLHours int  `json:"email_verification_ttl_hours"`
+	LockoutMaxFailures        int  `json:"lockout_max_failures"`    // failed logins per account before a lockout
+	LockoutIPMaxFailures      int  `json:"lockout_ip_max_failures"` // failed logins per client IP before a lockout
+	LockoutDurationMinutes    int  `json:"lockout_duration_minutes"`
+	BackoffBaseSeconds        int  `json:"backoff_base_seconds"` // delay after a failure, doubled each time
+	BackoffMaxSeconds         int  `json:"backoff_max_seconds"`
+	OTPTTLMinutes             int  `json:"otp_ttl_minutes"`       // lifetime of SMS login codes
+	OTPMaxAttempts            int  `json:"otp_max_attempts"`      // wrong entries before a code is burned
+	OTPResendSeconds          int  `json:"otp_resend_seconds"`    // minimum time between codes for one number
+	OTPRequestsPerHour        int  `json:"otp_requests_per_hour"` // codes one number can request per hour
+}
+
+// MailConfig holds outgoing email configuration
+type MailConfig struct {
+	Driver    string `json:"driver"` // smtp, outbox
+	Host      string `json:"host"`
+	Port      string `json:"port"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	From      string `json:"from"`
+	OutboxDir string `json:"outbox_dir"` // used by the outbox driver
+}
+
+// SEOConfig holds storefront details used in structured data and Open Graph tags
+type SEOConfig struct {
+	SiteName string `json:"site_name"`
+	Currency string `json:"currency"` // ISO 4217 code of all prices
+}
+
+// SMSConfig holds outgoing text message configuration
+type SMSConfig struct {
+	Driver    string `json:"driver"`     // console, file
+	OutboxDir string `json:"outbox_dir"` // used by the file driver
 }
 
 // LoadConfig loads configuration from environment variables
@@ -68,13 +113,46 @@ func LoadConfig() (*Config, error) {
 			TimeZone: getEnv("DB_TIMEZONE", "Asia/Kolkata"),
 		},
 		JWT: JWTConfig{
-			Secret:     getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
-			ExpiryHour: getEnvAsInt("JWT_EXPIRY_HOUR", 24),
+			Secret:            getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+			ExpiryHour:        getEnvAsInt("JWT_EXPIRY_HOUR", 24),
+			RefreshExpiryHour: getEnvAsInt("JWT_REFRESH_EXPIRY_HOUR", 720),
 		},
 		App: AppConfig{
-			Name:    getEnv("APP_NAME", "zneha-backend"),
-			Version: getEnv("APP_VERSION", "1.0.0"),
-			Debug:   getEnvAsBool("DEBUG", true),
+			Name:        getEnv("APP_NAME", "zneha-backend"),
+			Version:     getEnv("APP_VERSION", "1.0.0"),
+			Debug:       getEnvAsBool("DEBUG", true),
+			FrontendURL: getEnv("FRONTEND_URL", "http://localhost:3000"),
+		},
+		Auth: AuthConfig{
+			RequireEmailVerification:  getEnvAsBool("AUTH_REQUIRE_EMAIL_VERIFICATION", false),
+			PasswordResetTTLMinutes:   getEnvAsInt("AUTH_PASSWORD_RESET_TTL_MINUTES", 30),
+			EmailVerificationTTLHours: getEnvAsInt("AUTH_EMAIL_VERIFICATION_TTL_HOURS", 48),
+			LockoutMaxFailures:        getEnvAsInt("AUTH_LOCKOUT_MAX_FAILURES", 5),
+			LockoutIPMaxFailures:      getEnvAsInt("AUTH_LOCKOUT_IP_MAX_FAILURES", 50),
+			LockoutDurationMinutes:    getEnvAsInt("AUTH_LOCKOUT_DURATION_MINUTES", 15),
+			BackoffBaseSeconds:        getEnvAsInt("AUTH_BACKOFF_BASE_SECONDS", 1),
+			BackoffMaxSeconds:         getEnvAsInt("AUTH_BACKOFF_MAX_SECONDS", 60),
+			OTPTTLMinutes:             getEnvAsInt("AUTH_OTP_TTL_MINUTES", 5),
+			OTPMaxAttempts:            getEnvAsInt("AUTH_OTP_MAX_ATTEMPTS", 5),
+			OTPResendSeconds:          getEnvAsInt("AUTH_OTP_RESEND_SECONDS", 60),
+			OTPRequestsPerHour:        getEnvAsInt("AUTH_OTP_REQUESTS_PER_HOUR", 5),
+		},
+		Mail: MailConfig{
+			Driver:    getEnv("MAIL_DRIVER", "outbox"),
+			Host:      getEnv("SMTP_HOST", "localhost"),
+			Port:      getEnv("SMTP_PORT", "587"),
+			Username:  getEnv("SMTP_USERNAME", ""),
+			Password:  getEnv("SMTP_PASSWORD", ""),
+			From:      getEnv("MAIL_FROM", "no-reply@zneha.local"),
+			OutboxDir: getEnv("MAIL_OUTBOX_DIR", "tmp/outbox"),
+		},
+		SMS: SMSConfig{
+			Driver:    getEnv("SMS_DRIVER", "console"),
+			OutboxDir: getEnv("SMS_OUTBOX_DIR", "tmp/sms"),
+		},
+		SEO: SEOConfig{
+			SiteName: getEnv("SEO_SITE_NAME", "Zneha"),
+			Currency: getEnv("SEO_CURRENCY", "INR"),
 		},
 	}
 
@@ -103,6 +181,18 @@ func (c *Config) Validate() error {
 	if c.Server.Port == "" {
 		return fmt.Errorf("server port is required")
 	}
+	if c.Auth.LockoutDurationMinutes <= 0 {
+		return fmt.Errorf("auth lockout duration must be positive")
+	}
+	if c.Mail.Driver != "smtp" && c.Mail.Driver != "outbox" {
+		return fmt.Errorf("mail driver must be smtp or outbox")
+	}
+	if c.SMS.Driver != "console" && c.SMS.Driver != "file" {
+		return fmt.Errorf("sms driver must be console or file")
+	}
+	if len(c.SEO.Currency) != 3 {
+		return fmt.Errorf("seo currency must be a three letter ISO 4217 code")
+	}
 	return nil
 }
 
@@ -1,36 +1,98 @@
 package container
 
 import (
+	"time"
+
 	"github.com/Durgarao310/zneha-backend/internal/api/controller"
+	"github.com/Durgarao310/zneha-backend/internal/api/middleware"
+	"github.com/Durgarao310/zneha-backend/internal/config"
 	"github.com/Durgarao310/zneha-backend/internal/repository"
 	"github.com/Durgarao310/zneha-backend/internal/service"
+	"github.com/Durgarao310/zneha-backend/pkg/jwt"
+	"github.com/Durgarao310/zneha-backend/pkg/mailer"
+	"github.com/Durgarao310/zneha-backend/pkg/sms"
 	"gorm.io/gorm"
 )
 
 // Container holds all application dependencies
 type Container struct {
+	Config *config.Config
+
+	// Shared utilities
+	JWTManager *jwt.Manager
+	Mailer     mailer.Mailer
+	SMSSender  sms.SMSSender
+
 	// Repositories
-	ProductRepo  repository.ProductRepository
-	CategoryRepo repository.CategoryRepository
-	MediaRepo    repository.MediaRepository
-	VariantRepo  repository.VariantRepository
+	ProductRepo   repository.ProductRepository
+	CategoryRepo  repository.CategoryRepository
+	MediaRepo     repository.MediaRepository
+	VariantRepo   repository.VariantRepository
+	UserRepo      repository.UserRepository
+	RefreshRepo   repository.RefreshTokenRepository
+	RoleRepo      repository.RoleRepository
+	UserTokenRepo repository.UserTokenRepository
+	RecoveryRepo  repository.RecoveryCodeRepository
+	ThrottleRepo  repository.LoginThrottleRepository
+	PhoneOTPRepo  repository.PhoneOTPRepository
+	AddressRepo   repository.AddressRepository
+	AccountRepo   repository.AccountRepository
+	APIKeyRepo    repository.APIKeyRepository
+	SlugRepo      repository.SlugRedirectRepository
+	OptionRepo    repository.OptionRepository
+	AttributeRepo repository.AttributeRepository
+	MetafieldRepo repository.MetafieldRepository
+	BrandRepo     repository.BrandRepository
+	SearchRepo    repository.SearchRepository
 
 	// Services
-	ProductService  service.ProductService
-	CategoryService service.CategoryService
-	MediaService    *service.MediaService
-	VariantService  *service.VariantService
+	ProductService   service.ProductService
+	CategoryService  service.CategoryService
+	SEOService       service.SEOService
+	OptionService    service.OptionService
+	AttributeService service.AttributeService
+	MetafieldService service.MetafieldService
+	BrandService     service.BrandService
+	SearchService    service.SearchService
+	MediaService     *service.MediaService
+	VariantService   *service.VariantService
+	AuthService      service.AuthService
+	TwoFactorService service.TwoFactorService
+	LoginThrottle    service.LoginThrottleService
+	AddressService   service.AddressService
+	AccountService   service.AccountService
+	RBACService      service.RBACService
+	APIKeyService    service.APIKeyService
 
 	// Controllers
-	ProductController  controller.ProductController
-	CategoryController *controller.CategoryController
-	MediaController    *controller.MediaController
-	VariantController  *controller.VariantController
+	ProductController   controller.ProductController
+	CategoryController  *controller.CategoryController
+	MediaController     *controller.MediaController
+	VariantController   *controller.VariantController
+	AuthController      *controller.AuthController
+	RoleController      *controller.RoleController
+	TwoFactorController *controller.TwoFactorController
+	LockoutController   *controller.LockoutController
+	AddressController   *controller.AddressController
+	AccountController   *controller.AccountController
+	OptionController    *controller.OptionController
+	AttributeController *controller.AttributeController
+	MetafieldController *controller.MetafieldController
+	BrandController     *controller.BrandController
+	SearchController    *controller.SearchController
+	APIKeyController    *controller.APIKeyController
+
+	// Middleware
+	AuthMiddleware *middleware.AuthMiddleware
 }
 
 // NewContainer creates and initializes all dependencies
-func NewContainer(db *gorm.DB) *Container {
-	c := &Container{}
+func NewContainer(db *gorm.DB, cfg *config.Config) *Container {
+	c := &Container{Config: cfg}
+
+	c.JWTManager = jwt.NewManager(cfg.JWT.Secret, cfg.App.Name, time.Duration(cfg.JWT.ExpiryHour)*time.Hour)
+	c.Mailer = newMailer(cfg.Mail)
+	c.SMSSender = newSMSSender(cfg.SMS)
 
 	// Initialize repositories
 	c.initRepositories(db)
@@ -41,6 +103,9 @@ func NewContainer(db *gorm.DB) *Container {
 	// Initialize controllers
 	c.initControllers()
 
+	// Initialize middleware
+	c.initMiddleware()
+
 	return c
 }
 
@@ -50,20 +115,113 @@ func (c *Container) initRepositories(db *gorm.DB) {
 	c.CategoryRepo = repository.NewCategoryRepository(db)
 	c.MediaRepo = repository.NewMediaRepository(db)
 	c.VariantRepo = repository.NewVariantRepository(db)
+	c.UserRepo = repository.NewUserRepository(db)
+	c.RefreshRepo = repository.NewRefreshTokenRepository(db)
+	c.RoleRepo = repository.NewRoleRepository(db)
+	c.UserTokenRepo = repository.NewUserTokenRepository(db)
+	c.RecoveryRepo = repository.NewRecoveryCodeRepository(db)
+	c.ThrottleRepo = repository.NewLoginThrottleRepository(db)
+	c.PhoneOTPRepo = repository.NewPhoneOTPRepository(db)
+	c.AddressRepo = repository.NewAddressRepository(db)
+	c.AccountRepo = repository.NewAccountRepository(db)
+	c.APIKeyRepo = repository.NewAPIKeyRepository(db)
+	c.SlugRepo = repository.NewSlugRedirectRepository(db)
+	c.OptionRepo = repository.NewOptionRepository(db)
+	c.AttributeRepo = repository.NewAttributeRepository(db)
+	c.MetafieldRepo = repository.NewMetafieldRepository(db)
+	c.BrandRepo = repository.NewBrandRepository(db)
+	c.SearchRepo = repository.NewSearchRepository(db)
 }
 
 // initServices initializes all service dependencies
 func (c *Container) initServices() {
-	c.ProductService = service.NewProductService(c.ProductRepo)
-	c.CategoryService = service.NewCategoryService(c.CategoryRepo)
+	c.ProductService = service.NewProductService(c.ProductRepo, c.CategoryRepo, c.SlugRepo, c.BrandRepo, c.AttributeRepo)
+	c.CategoryService = service.NewCategoryService(c.CategoryRepo, c.SlugRepo)
+	c.SEOService = service.NewSEOService(c.ProductRepo, c.CategoryRepo, c.VariantRepo, c.MediaRepo, service.SEOOptions{
+		BaseURL:  c.Config.App.FrontendURL,
+		SiteName: c.Config.SEO.SiteName,
+		Currency: c.Config.SEO.Currency,
+	})
 	c.MediaService = service.NewMediaService(c.MediaRepo)
 	c.VariantService = service.NewVariantService(c.VariantRepo)
+	c.OptionService = service.NewOptionService(c.OptionRepo, c.ProductRepo, c.VariantRepo)
+	c.AttributeService = service.NewAttributeService(c.AttributeRepo, c.CategoryRepo, c.ProductRepo)
+	c.MetafieldService = service.NewMetafieldService(c.MetafieldRepo)
+	c.BrandService = service.NewBrandService(c.BrandRepo, c.ProductRepo, c.MediaRepo, c.SlugRepo)
+	c.SearchService = service.NewSearchService(c.SearchRepo)
+	c.TwoFactorService = service.NewTwoFactorService(c.UserRepo, c.RecoveryRepo, c.Config.App.Name)
+	c.LoginThrottle = service.NewLoginThrottleService(c.ThrottleRepo, c.UserRepo, service.LockoutPolicy{
+		MaxFailures:     c.Config.Auth.LockoutMaxFailures,
+		IPMaxFailures:   c.Config.Auth.LockoutIPMaxFailures,
+		LockoutDuration: time.Duration(c.Config.Auth.LockoutDurationMinutes) * time.Minute,
+		BackoffBase:     time.Duration(c.Config.Auth.BackoffBaseSeconds) * time.Second,
+		BackoffMax:      time.Duration(c.Config.Auth.BackoffMaxSeconds) * time.Second,
+	})
+	c.AuthService = service.NewAuthService(
+		c.UserRepo,
+		c.RefreshRepo,
+		c.UserTokenRepo,
+		c.PhoneOTPRepo,
+		c.TwoFactorService,
+		c.LoginThrottle,
+		c.JWTManager,
+		c.Mailer,
+		c.SMSSender,
+		service.AuthOptions{
+			RefreshTTL:               time.Duration(c.Config.JWT.RefreshExpiryHour) * time.Hour,
+			PasswordResetTTL:         time.Duration(c.Config.Auth.PasswordResetTTLMinutes) * time.Minute,
+			EmailVerificationTTL:     time.Duration(c.Config.Auth.EmailVerificationTTLHours) * time.Hour,
+			RequireEmailVerification: c.Config.Auth.RequireEmailVerification,
+			FrontendURL:              c.Config.App.FrontendURL,
+			OTPTTL:                   time.Duration(c.Config.Auth.OTPTTLMinutes) * time.Minute,
+			OTPMaxAttempts:           c.Config.Auth.OTPMaxAttempts,
+			OTPResendInterval:        time.Duration(c.Config.Auth.OTPResendSeconds) * time.Second,
+			OTPRequestsPerHour:       c.Config.Auth.OTPRequestsPerHour,
+		},
+	)
+	c.RBACService = service.NewRBACService(c.RoleRepo, c.UserRepo)
+	c.APIKeyService = service.NewAPIKeyService(c.APIKeyRepo)
+	c.AddressService = service.NewAddressService(c.AddressRepo)
+	c.AccountService = service.NewAccountService(c.AccountRepo, c.UserRepo, c.TwoFactorService, c.LoginThrottle)
 }
 
 // initControllers initializes all controller dependencies
 func (c *Container) initControllers() {
-	c.ProductController = controller.NewProductController(c.ProductService)
-	c.CategoryController = controller.NewCategoryController(c.CategoryService)
+	c.ProductController = controller.NewProductController(c.ProductService, c.SEOService)
+	c.CategoryController = controller.NewCategoryController(c.CategoryService, c.ProductService)
 	c.MediaController = controller.NewMediaController(c.MediaService)
 	c.VariantController = controller.NewVariantController(c.VariantService)
+	c.AuthController = controller.NewAuthController(c.AuthService)
+	c.RoleController = controller.NewRoleController(c.RBACService)
+	c.TwoFactorController = controller.NewTwoFactorController(c.TwoFactorService, c.AuthController)
+	c.APIKeyController = controller.NewAPIKeyController(c.APIKeyService)
+	c.LockoutController = controller.NewLockoutController(c.LoginThrottle)
+	c.AddressController = controller.NewAddressController(c.AddressService)
+	c.AccountController = controller.NewAccountController(c.AccountService)
+	c.OptionController = controller.NewOptionController(c.OptionService)
+	c.AttributeController = controller.NewAttributeController(c.AttributeService)
+	c.MetafieldController = controller.NewMetafieldController(c.MetafieldService)
+	c.BrandController = controller.NewBrandController(c.BrandService)
+	c.SearchController = controller.NewSearchController(c.SearchService)
+}
+
+// initMiddleware initializes middleware that depends on services
+func (c *Container) initMiddleware() {
+	c.AuthMiddleware = middleware.NewAuthMiddleware(c.AuthService, c.RBACService, c.APIKeyService)
+}
+
+// newMailer selects the mail transport configured for the environment
+func newMailer(cfg config.MailConfig) mailer.Mailer {
+	if cfg.Driver == "smtp" {
+		return mailer.NewSMTPMailer(cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.From)
+	}
+	return mailer.NewOutboxMailer(cfg.OutboxDir, cfg.From)
+}
+
+// newSMSSender selects the text message transport configured for the environment
+func newSMSSender(cfg config.SMSConfig) sms.SMSSender {
+	if cfg.Driver == "file" {
+		return sms.NewFileSender(cfg.OutboxDir)
+	}
+	return sms.NewConsoleSender()
 }
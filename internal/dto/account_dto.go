@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+)
+
+// DeleteAccountRequest represents payload confirming an account deletion
+type DeleteAccountRequest struct {
+	Password string `json:"password,omitempty"`                   // required for accounts with a password
+	Code     string `json:"code,omitempty"`                       // TOTP or recovery code, required with two-factor authentication
+	Confirm  string `json:"confirm" binding:"required,eq=DELETE"` // guards against accidental calls
+}
+
+// SessionExport is a login session without its token material
+type SessionExport struct {
+	SessionID string     `json:"sessionId"`
+	IPAddress string     `json:"ipAddress"`
+	UserAgent string     `json:"userAgent"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	RevokedAt *time.Time `json:"revokedAt"`
+}
+
+// LoginCodeExport is an SMS login code request without the code
+type LoginCodeExport struct {
+	IPAddress  string     `json:"ipAddress"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ConsumedAt *time.Time `json:"consumedAt"`
+}
+
+// AccountExportResponse is the personal data archive of a user
+type AccountExportResponse struct {
+	ExportedAt     string               `json:"exportedAt"`
+	User           UserResponse         `json:"user"`
+	Roles          []string             `json:"roles"`
+	Addresses      []model.Address      `json:"addresses"`
+	Sessions       []SessionExport      `json:"sessions"`
+	LoginCodes     []LoginCodeExport    `json:"loginCodes"`
+	LockoutEvents  []model.LockoutEvent `json:"lockoutEvents"`
+	APIKeysCreated []model.APIKey       `json:"apiKeysCreated"`
+}
+
+// ToAccountExportResponse converts the collected account data to the export archive
+func ToAccountExportResponse(data *repository.AccountData) AccountExportResponse {
+	out := AccountExportResponse{
+		ExportedAt:     time.Now().UTC().Format("2006-01-02T15:04:05Z07:00"),
+		User:           ToUserResponse(data.User),
+		Roles:          make([]string, 0, len(data.Roles)),
+		Addresses:      data.Addresses,
+		Sessions:       make([]SessionExport, 0, len(data.Sessions)),
+		LoginCodes:     make([]LoginCodeExport, 0, len(data.LoginCodes)),
+		LockoutEvents:  data.LockoutEvents,
+		APIKeysCreated: data.APIKeysCreated,
+	}
+	for _, role := range data.Roles {
+		out.Roles = append(out.Roles, role.Name)
+	}
+	for _, token := range data.Sessions {
+		out.Sessions = append(out.Sessions, SessionExport{
+			SessionID: token.FamilyID,
+			IPAddress: token.IPAddress,
+			UserAgent: token.UserAgent,
+			CreatedAt: token.CreatedAt,
+			ExpiresAt: token.ExpiresAt,
+			RevokedAt: token.RevokedAt,
+		})
+	}
+	for _, code := range data.LoginCodes {
+		out.LoginCodes = append(out.LoginCodes, LoginCodeExport{
+			IPAddress:  code.IPAddress,
+			CreatedAt:  code.CreatedAt,
+			ConsumedAt: code.ConsumedAt,
+		})
+	}
+	return out
+}
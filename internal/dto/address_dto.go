@@ -0,0 +1,38 @@
+package dto
+
+import "github.com/Durgarao310/zneha-backend/internal/model"
+
+// AddressRequest represents payload for creating or updating an address.
+// Only Indian addresses are supported for now.
+type AddressRequest struct {
+	Label             string `json:"label,omitempty" binding:"max=50"`
+	FullName          string `json:"fullName" binding:"required,max=255"`
+	Phone             string `json:"phone" binding:"required,in_phone"`
+	Line1             string `json:"line1" binding:"required,max=255"`
+	Line2             string `json:"line2,omitempty" binding:"max=255"`
+	Landmark          string `json:"landmark,omitempty" binding:"max=255"`
+	City              string `json:"city" binding:"required,max=100"`
+	State             string `json:"state" binding:"required,max=100"`
+	Pincode           string `json:"pincode" binding:"required,in_pincode"`
+	Country           string `json:"country,omitempty" binding:"omitempty,oneof=IN"`
+	IsDefaultShipping bool   `json:"isDefaultShipping"`
+	IsDefaultBilling  bool   `json:"isDefaultBilling"`
+}
+
+// ToModel converts the request to an address model
+func (r AddressRequest) ToModel() *model.Address {
+	return &model.Address{
+		Label:             r.Label,
+		FullName:          r.FullName,
+		Phone:             r.Phone,
+		Line1:             r.Line1,
+		Line2:             r.Line2,
+		Landmark:          r.Landmark,
+		City:              r.City,
+		State:             r.State,
+		Pincode:           r.Pincode,
+		Country:           r.Country,
+		IsDefaultShipping: r.IsDefaultShipping,
+		IsDefaultBilling:  r.IsDefaultBilling,
+	}
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+)
+
+// APIKeyCreateRequest represents payload for creating an API key
+type APIKeyCreateRequest struct {
+	Name      string     `json:"name" binding:"required,min=2,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,dive,required"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// APIKeyCreatedResponse returns the newly created key including its secret.
+// The key is only shown once.
+type APIKeyCreatedResponse struct {
+	Key    string       `json:"key"`
+	APIKey model.APIKey `json:"apiKey"`
+}
@@ -0,0 +1,96 @@
+package dto
+
+import "github.com/Durgarao310/zneha-backend/internal/model"
+
+// AttributeCreateRequest represents payload for defining a product attribute
+type AttributeCreateRequest struct {
+	Code    string   `json:"code" binding:"required,max=100"` // lowercase key used in product payloads, e.g. fabric
+	Name    string   `json:"name" binding:"required,max=255"`
+	Type    string   `json:"type" binding:"required,oneof=text number boolean enum"`
+	Unit    string   `json:"unit,omitempty" binding:"max=20"`                           // number attributes only
+	Options []string `json:"options,omitempty" binding:"max=200,dive,required,max=255"` // enum attributes only
+}
+
+// AttributeUpdateRequest represents payload for updating an attribute. The code
+// and type cannot change; options replace the current list in the given order.
+type AttributeUpdateRequest struct {
+	Name    string   `json:"name" binding:"required,max=255"`
+	Unit    string   `json:"unit,omitempty" binding:"max=20"`
+	Options []string `json:"options,omitempty" binding:"max=200,dive,required,max=255"`
+}
+
+// CategoryAttributeRequest assigns one attribute to a category
+type CategoryAttributeRequest struct {
+	AttributeID uint64 `json:"attributeId" binding:"required"`
+	IsRequired  bool   `json:"isRequired"`
+}
+
+// CategoryAttributesRequest lists every attribute assigned directly to a category, in display order
+type CategoryAttributesRequest struct {
+	Attributes []CategoryAttributeRequest `json:"attributes" binding:"max=100,dive"`
+}
+
+// ProductAttributesRequest sets a product's attribute values keyed by attribute code.
+// Attributes left out or set to null are cleared.
+type ProductAttributesRequest struct {
+	Values map[string]any `json:"values" binding:"max=100"`
+}
+
+// ProductAttributeResponse is one attribute value shown on a product
+type ProductAttributeResponse struct {
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Unit  string `json:"unit,omitempty"`
+	Value any    `json:"value"`
+}
+
+// ToModel converts the request to an attribute model with its options
+func (r AttributeCreateRequest) ToModel() *model.Attribute {
+	return &model.Attribute{Code: r.Code, Name: r.Name, Type: r.Type, Unit: r.Unit, Options: toAttributeOptions(r.Options)}
+}
+
+// ToModel converts the request to an attribute model for the given ID
+func (r AttributeUpdateRequest) ToModel(id uint64) *model.Attribute {
+	return &model.Attribute{ID: id, Name: r.Name, Unit: r.Unit, Options: toAttributeOptions(r.Options)}
+}
+
+// ToModel converts the request to category attribute links
+func (r CategoryAttributesRequest) ToModel(categoryID uint64) []model.CategoryAttribute {
+	links := make([]model.CategoryAttribute, 0, len(r.Attributes))
+	for _, attribute := range r.Attributes {
+		links = append(links, model.CategoryAttribute{
+			CategoryID:  categoryID,
+			AttributeID: attribute.AttributeID,
+			IsRequired:  attribute.IsRequired,
+		})
+	}
+	return links
+}
+
+// ToProductAttributeResponses converts stored attribute values for product responses
+func ToProductAttributeResponses(values []model.ProductAttributeValue) []ProductAttributeResponse {
+	out := make([]ProductAttributeResponse, 0, len(values))
+	for i := range values {
+		attribute := values[i].Attribute
+		if attribute == nil {
+			continue
+		}
+		out = append(out, ProductAttributeResponse{
+			Code:  attribute.Code,
+			Name:  attribute.Name,
+			Type:  attribute.Type,
+			Unit:  attribute.Unit,
+			Value: values[i].Value(),
+		})
+	}
+	return out
+}
+
+func toAttributeOptions(values []string) []model.AttributeOption {
+	options := make([]model.AttributeOption, 0, len(values))
+	for _, value := range values {
+		options = append(options, model.AttributeOption{Value: value})
+	}
+	return options
+}
@@ -0,0 +1,162 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+)
+
+// RegisterRequest represents payload for registering a new user
+type RegisterRequest struct {
+	Email    string `json:"email" binding:"required,email,max=255"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+	Name     string `json:"name,omitempty" binding:"max=255"`
+}
+
+// LoginRequest represents payload for password login
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RefreshTokenRequest represents payload carrying a refresh token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+// ForgotPasswordRequest represents payload for requesting a password reset email
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest represents payload for setting a new password with a reset token
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+// VerifyEmailRequest represents payload for confirming an email address
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// PhoneOTPRequest represents payload for requesting an SMS login code
+type PhoneOTPRequest struct {
+	Phone string `json:"phone" binding:"required,max=20"`
+}
+
+// PhoneOTPVerifyRequest represents payload for logging in with an SMS code
+type PhoneOTPVerifyRequest struct {
+	Phone string `json:"phone" binding:"required,max=20"`
+	Code  string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// PhoneOTPSentResponse is returned once a login code has been sent
+type PhoneOTPSentResponse struct {
+	Message   string `json:"message"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+// MFAVerifyRequest represents payload for completing a login with a second factor
+type MFAVerifyRequest struct {
+	MFAToken string `json:"mfaToken" binding:"required"`
+	Code     string `json:"code" binding:"required"` // TOTP code or recovery code
+}
+
+// TwoFactorCodeRequest represents payload carrying a TOTP or recovery code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// MFAChallengeResponse is returned by login when a second factor is required
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfaRequired"`
+	MFAToken    string `json:"mfaToken"`
+	ExpiresAt   string `json:"expiresAt"`
+}
+
+// TOTPEnrollmentResponse carries the secret to set up an authenticator app
+type TOTPEnrollmentResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURL string `json:"otpauthUrl"` // encode as a QR code for authenticator apps
+}
+
+// RecoveryCodesResponse carries one-time recovery codes; they are only shown once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// UserResponse represents user data returned to clients
+type UserResponse struct {
+	ID               uint64  `json:"id"`
+	Email            string  `json:"email"`
+	Phone            *string `json:"phone"`
+	Name             string  `json:"name"`
+	IsActive         bool    `json:"isActive"`
+	EmailVerifiedAt  *string `json:"emailVerifiedAt"`
+	PhoneVerifiedAt  *string `json:"phoneVerifiedAt"`
+	TwoFactorEnabled bool    `json:"twoFactorEnabled"`
+	LastLoginAt      *string `json:"lastLoginAt"`
+	CreatedAt        string  `json:"createdAt"`
+	UpdatedAt        string  `json:"updatedAt"`
+}
+
+// AuthResponse represents the token payload returned after a successful login
+type AuthResponse struct {
+	AccessToken      string       `json:"accessToken"`
+	TokenType        string       `json:"tokenType"`
+	ExpiresAt        string       `json:"expiresAt"`
+	RefreshToken     string       `json:"refreshToken"`
+	RefreshExpiresAt string       `json:"refreshExpiresAt"`
+	User             UserResponse `json:"user"`
+}
+
+// ToUserResponse converts model to response DTO
+func ToUserResponse(m *model.User) UserResponse {
+	if m == nil {
+		return UserResponse{}
+	}
+	return UserResponse{
+		ID:               m.ID,
+		Email:            m.Email,
+		Phone:            m.Phone,
+		Name:             m.Name,
+		IsActive:         m.IsActive,
+		EmailVerifiedAt:  formatOptionalTime(m.EmailVerifiedAt),
+		PhoneVerifiedAt:  formatOptionalTime(m.PhoneVerifiedAt),
+		TwoFactorEnabled: m.TOTPEnabledAt != nil,
+		LastLoginAt:      formatOptionalTime(m.LastLoginAt),
+		CreatedAt:        m.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:        m.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// formatOptionalTime formats a nullable timestamp
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted
+}
+
+// ToMFAChallengeResponse builds the response asking for a second factor
+func ToMFAChallengeResponse(mfaToken string, expiresAt time.Time) MFAChallengeResponse {
+	return MFAChallengeResponse{
+		MFARequired: true,
+		MFAToken:    mfaToken,
+		ExpiresAt:   expiresAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// ToAuthResponse builds the login response from an issued token pair
+func ToAuthResponse(user *model.User, accessToken string, expiresAt time.Time, refreshToken string, refreshExpiresAt time.Time) AuthResponse {
+	return AuthResponse{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresAt:        expiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		User:             ToUserResponse(user),
+	}
+}
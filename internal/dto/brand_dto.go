@@ -0,0 +1,44 @@
+package dto
+
+import "github.com/Durgarao310/zneha-backend/internal/model"
+
+// BrandRequest represents payload for creating or updating a brand
+type BrandRequest struct {
+	Name        string  `json:"name" binding:"required,max=255"`
+	Slug        string  `json:"slug,omitempty" binding:"max=200"` // generated from the name on create, kept on update when empty
+	Description string  `json:"description,omitempty" binding:"max=5000"`
+	LogoMediaID *uint64 `json:"logoMediaId,omitempty"`
+}
+
+// BrandSlugResponse is a brand found by slug. Moved is true when the slug was
+// an earlier one and clients should link to the current slug instead.
+type BrandSlugResponse struct {
+	model.Brand
+	Moved bool `json:"moved"`
+}
+
+// BrandSummaryResponse is the brand shown on a product
+type BrandSummaryResponse struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// ToModel converts the request to a brand model
+func (r BrandRequest) ToModel(id uint64) *model.Brand {
+	return &model.Brand{
+		ID:          id,
+		Name:        r.Name,
+		Slug:        r.Slug,
+		Description: r.Description,
+		LogoMediaID: r.LogoMediaID,
+	}
+}
+
+// ToBrandSummaryResponse converts a preloaded brand, returning nil when there is none
+func ToBrandSummaryResponse(m *model.Brand) *BrandSummaryResponse {
+	if m == nil {
+		return nil
+	}
+	return &BrandSummaryResponse{ID: m.ID, Name: m.Name, Slug: m.Slug}
+}
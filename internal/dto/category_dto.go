@@ -0,0 +1,95 @@
+package dto
+
+import (
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+)
+
+// CategoryRequest represents payload for creating or updating a category
+type CategoryRequest struct {
+	Name            string  `json:"name" binding:"required,max=255"`
+	Slug            string  `json:"slug,omitempty" binding:"max=200"` // generated from the name on create, kept on update when empty
+	Description     string  `json:"description,omitempty"`
+	MetaTitle       string  `json:"metaTitle,omitempty" binding:"max=255"`
+	MetaDescription string  `json:"metaDescription,omitempty" binding:"max=500"`
+	CanonicalURL    string  `json:"canonicalUrl,omitempty" binding:"omitempty,url,max=500"`
+	ParentID        *uint64 `json:"parentId"` // null for a root category; a change on update moves the subtree
+}
+
+// ToModel converts the request to a category model
+func (r CategoryRequest) ToModel(id uint64) *model.Category {
+	return &model.Category{
+		ID:              id,
+		Name:            r.Name,
+		Slug:            r.Slug,
+		Description:     r.Description,
+		MetaTitle:       r.MetaTitle,
+		MetaDescription: r.MetaDescription,
+		CanonicalURL:    r.CanonicalURL,
+		ParentID:        r.ParentID,
+	}
+}
+
+// CategoryMoveRequest re-parents a category. Omit parentId or send null to make it a root category.
+type CategoryMoveRequest struct {
+	ParentID *uint64 `json:"parentId"`
+}
+
+// CategoryReorderRequest lists every child of parentId (null for root categories) in the new order
+type CategoryReorderRequest struct {
+	ParentID *uint64  `json:"parentId"`
+	IDs      []uint64 `json:"ids" binding:"required,min=1"`
+}
+
+// CategorySlugResponse is a category found by slug. Moved is true when the slug was
+// an earlier one and clients should link to the current slug instead.
+type CategorySlugResponse struct {
+	model.Category
+	Moved bool `json:"moved"`
+}
+
+// CategoryTreeNode is a category with its nested subcategories
+type CategoryTreeNode struct {
+	ID           uint64              `json:"id"`
+	Name         string              `json:"name"`
+	Slug         string              `json:"slug"`
+	Description  string              `json:"description"`
+	ParentID     *uint64             `json:"parentId"`
+	Depth        int                 `json:"depth"`
+	Path         string              `json:"path"`
+	Position     int                 `json:"position"`
+	ProductCount int64               `json:"productCount"` // active products here or in any subcategory
+	Children     []*CategoryTreeNode `json:"children"`
+}
+
+// ToCategoryTree nests rows ordered parents first. Rows whose parent is not in
+// the list become top-level nodes.
+func ToCategoryTree(rows []repository.CategoryWithProductCount) []*CategoryTreeNode {
+	nodes := make(map[uint64]*CategoryTreeNode, len(rows))
+	tree := []*CategoryTreeNode{}
+
+	for _, row := range rows {
+		node := &CategoryTreeNode{
+			ID:           row.ID,
+			Name:         row.Name,
+			Slug:         row.Slug,
+			Description:  row.Description,
+			ParentID:     row.ParentID,
+			Depth:        row.Depth,
+			Path:         row.Path,
+			Position:     row.Position,
+			ProductCount: row.ProductCount,
+			Children:     []*CategoryTreeNode{},
+		}
+		nodes[row.ID] = node
+
+		if row.ParentID != nil {
+			if parent, ok := nodes[*row.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		tree = append(tree, node)
+	}
+	return tree
+}
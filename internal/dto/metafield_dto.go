@@ -0,0 +1,79 @@
+package dto
+
+import "github.com/Durgarao310/zneha-backend/internal/model"
+
+// MetafieldDefinitionCreateRequest represents payload for declaring a metafield
+type MetafieldDefinitionCreateRequest struct {
+	OwnerType   string `json:"ownerType" binding:"required,oneof=product variant category media"`
+	Namespace   string `json:"namespace" binding:"required,max=50"`
+	Key         string `json:"key" binding:"required,max=50"`
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description,omitempty" binding:"max=500"`
+	Type        string `json:"type" binding:"required,oneof=single_line_text multi_line_text integer decimal boolean date url json"`
+	Visibility  string `json:"visibility,omitempty" binding:"omitempty,oneof=public admin"` // defaults to admin
+}
+
+// MetafieldDefinitionUpdateRequest represents payload for updating a definition.
+// The owner type, namespace, key and type cannot change.
+type MetafieldDefinitionUpdateRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description,omitempty" binding:"max=500"`
+	Visibility  string `json:"visibility" binding:"required,oneof=public admin"`
+}
+
+// MetafieldValueRequest represents payload for setting a metafield on a resource
+type MetafieldValueRequest struct {
+	Value any `json:"value"` // must match the definition type
+}
+
+// MetafieldResponse is a metafield value with the definition fields clients need
+type MetafieldResponse struct {
+	Namespace  string `json:"namespace"`
+	Key        string `json:"key"`
+	Type       string `json:"type"`
+	Visibility string `json:"visibility"`
+	Value      any    `json:"value"`
+	UpdatedAt  string `json:"updatedAt"`
+}
+
+// ToModel converts the request to a definition model
+func (r MetafieldDefinitionCreateRequest) ToModel() *model.MetafieldDefinition {
+	return &model.MetafieldDefinition{
+		OwnerType:   r.OwnerType,
+		Namespace:   r.Namespace,
+		Key:         r.Key,
+		Name:        r.Name,
+		Description: r.Description,
+		Type:        r.Type,
+		Visibility:  r.Visibility,
+	}
+}
+
+// ToModel converts the request to a definition model for the given ID
+func (r MetafieldDefinitionUpdateRequest) ToModel(id uint64) *model.MetafieldDefinition {
+	return &model.MetafieldDefinition{ID: id, Name: r.Name, Description: r.Description, Visibility: r.Visibility}
+}
+
+// ToMetafieldResponse converts a metafield with its definition loaded
+func ToMetafieldResponse(m *model.Metafield) MetafieldResponse {
+	if m == nil || m.Definition == nil {
+		return MetafieldResponse{}
+	}
+	return MetafieldResponse{
+		Namespace:  m.Definition.Namespace,
+		Key:        m.Definition.Key,
+		Type:       m.Definition.Type,
+		Visibility: m.Definition.Visibility,
+		Value:      m.Value,
+		UpdatedAt:  m.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// ToMetafieldResponseList converts slice of metafields to slice of responses
+func ToMetafieldResponseList(list []model.Metafield) []MetafieldResponse {
+	out := make([]MetafieldResponse, 0, len(list))
+	for i := range list {
+		out = append(out, ToMetafieldResponse(&list[i]))
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package dto
+
+import "github.com/Durgarao310/zneha-backend/internal/model"
+
+// OptionValueRequest represents payload for adding a value to an option type
+type OptionValueRequest struct {
+	Value string `json:"value" binding:"required,max=100"`
+	Code  string `json:"code,omitempty" binding:"max=50"` // SKU code, derived from the value when empty
+}
+
+// OptionTypeCreateRequest represents payload for adding an option type to a product
+type OptionTypeCreateRequest struct {
+	Name   string               `json:"name" binding:"required,max=100,excludesall={}"`
+	Values []OptionValueRequest `json:"values,omitempty" binding:"max=100,dive"`
+}
+
+// OptionTypeUpdateRequest represents payload for renaming an option type
+type OptionTypeUpdateRequest struct {
+	Name string `json:"name" binding:"required,max=100,excludesall={}"`
+}
+
+// OptionTypeReorderRequest lists every option type of a product in the new order
+type OptionTypeReorderRequest struct {
+	IDs []uint64 `json:"ids" binding:"required,min=1"`
+}
+
+// OptionValueReorderRequest lists every value of an option type in the new order
+type OptionValueReorderRequest struct {
+	IDs []uint64 `json:"ids" binding:"required,min=1"`
+}
+
+// VariantOptionsRequest links a variant to one value of every option type of its product
+type VariantOptionsRequest struct {
+	OptionValueIDs []uint64 `json:"optionValueIds" binding:"required,min=1"`
+}
+
+// GenerateVariantsRequest represents payload for generating a product's variant matrix
+type GenerateVariantsRequest struct {
+	SKUTemplate   string  `json:"skuTemplate,omitempty" binding:"max=100"` // defaults to {product}-{values}
+	Price         float64 `json:"price" binding:"required,gt=0"`
+	StockQuantity int     `json:"stockQuantity" binding:"min=0"`
+}
+
+// GenerateVariantsResponse reports the variants created and the combinations that already existed
+type GenerateVariantsResponse struct {
+	Created []model.Variant `json:"created"`
+	Skipped int             `json:"skipped"`
+}
+
+// ToModel converts the request to an option value model
+func (r OptionValueRequest) ToModel(optionTypeID uint64) *model.OptionValue {
+	return &model.OptionValue{OptionTypeID: optionTypeID, Value: r.Value, Code: r.Code}
+}
+
+// ToModel converts the request to an option type model with its values
+func (r OptionTypeCreateRequest) ToModel(productID uint64) *model.OptionType {
+	values := make([]model.OptionValue, 0, len(r.Values))
+	for _, value := range r.Values {
+		values = append(values, model.OptionValue{Value: value.Value, Code: value.Code})
+	}
+	return &model.OptionType{ProductID: productID, Name: r.Name, Values: values}
+}
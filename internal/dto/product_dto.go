@@ -1,32 +1,77 @@
 package dto
 
-import "github.com/Durgarao310/zneha-backend/internal/model"
+import (
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+)
+
+// ProductListQuery holds the filters accepted by the product listing. Brand and
+// category may be repeated to match any of them. Attribute filters arrive as
+// attr[code]=value and are read separately.
+type ProductListQuery struct {
+	BrandIDs    []uint64 `form:"brandId"`
+	CategoryIDs []uint64 `form:"categoryId"`
+	Status      string   `form:"status" binding:"omitempty,oneof=active inactive"`
+	MinPrice    *float64 `form:"minPrice" binding:"omitempty,gte=0"`
+	MaxPrice    *float64 `form:"maxPrice" binding:"omitempty,gte=0"`
+	InStock     bool     `form:"inStock"`
+}
 
 // ProductCreateRequest represents payload for creating a product
 type ProductCreateRequest struct {
-	Name             string `json:"name" binding:"required,min=3,max=255,printascii"`
-	Description      string `json:"description,omitempty" binding:"max=1000"`
-	ShortDescription string `json:"shortDescription,omitempty" binding:"max=255"`
-	Status           string `json:"status,omitempty" binding:"omitempty,oneof=active inactive"`
+	Name              string   `json:"name" binding:"required,min=3,max=255,printascii"`
+	Slug              string   `json:"slug,omitempty" binding:"max=200"` // generated from the name when empty
+	Description       string   `json:"description,omitempty" binding:"max=1000"`
+	ShortDescription  string   `json:"shortDescription,omitempty" binding:"max=255"`
+	Status            string   `json:"status,omitempty" binding:"omitempty,oneof=active inactive"`
+	MetaTitle         string   `json:"metaTitle,omitempty" binding:"max=255"`
+	MetaDescription   string   `json:"metaDescription,omitempty" binding:"max=500"`
+	CanonicalURL      string   `json:"canonicalUrl,omitempty" binding:"omitempty,url,max=500"`
+	BrandID           *uint64  `json:"brandId,omitempty"`
+	CategoryIDs       []uint64 `json:"categoryIds,omitempty" binding:"max=50"`
+	PrimaryCategoryID *uint64  `json:"primaryCategoryId,omitempty"` // defaults to the first category
 }
 
 // ProductUpdateRequest represents payload for updating a product
 type ProductUpdateRequest struct {
-	Name             string `json:"name" binding:"required,min=3,max=255,printascii"`
-	Description      string `json:"description,omitempty" binding:"max=1000"`
-	ShortDescription string `json:"shortDescription,omitempty" binding:"max=255"`
-	Status           string `json:"status,omitempty" binding:"omitempty,oneof=active inactive"`
+	Name              string   `json:"name" binding:"required,min=3,max=255,printascii"`
+	Slug              string   `json:"slug,omitempty" binding:"max=200"` // omit to keep the current slug
+	Description       string   `json:"description,omitempty" binding:"max=1000"`
+	ShortDescription  string   `json:"shortDescription,omitempty" binding:"max=255"`
+	Status            string   `json:"status,omitempty" binding:"omitempty,oneof=active inactive"`
+	MetaTitle         string   `json:"metaTitle,omitempty" binding:"max=255"`
+	MetaDescription   string   `json:"metaDescription,omitempty" binding:"max=500"`
+	CanonicalURL      string   `json:"canonicalUrl,omitempty" binding:"omitempty,url,max=500"`
+	BrandID           *uint64  `json:"brandId,omitempty"`
+	CategoryIDs       []uint64 `json:"categoryIds" binding:"max=50"` // omit to keep, [] to clear
+	PrimaryCategoryID *uint64  `json:"primaryCategoryId,omitempty"`
 }
 
 // ProductResponse represents product data returned to clients
 type ProductResponse struct {
-	ID               uint64 `json:"id"`
-	Name             string `json:"name"`
-	Description      string `json:"description"`
-	ShortDescription string `json:"shortDescription"`
-	Status           string `json:"status"`
-	CreatedAt        string `json:"createdAt"`
-	UpdatedAt        string `json:"updatedAt"`
+	ID                uint64                     `json:"id"`
+	Name              string                     `json:"name"`
+	Slug              string                     `json:"slug"`
+	Description       string                     `json:"description"`
+	ShortDescription  string                     `json:"shortDescription"`
+	Status            string                     `json:"status"`
+	MetaTitle         string                     `json:"metaTitle"`
+	MetaDescription   string                     `json:"metaDescription"`
+	CanonicalURL      string                     `json:"canonicalUrl"`
+	BrandID           *uint64                    `json:"brandId"`
+	Brand             *BrandSummaryResponse      `json:"brand"`
+	CategoryIDs       []uint64                   `json:"categoryIds"`
+	PrimaryCategoryID *uint64                    `json:"primaryCategoryId"`
+	Attributes        []ProductAttributeResponse `json:"attributes"`
+	CreatedAt         string                     `json:"createdAt"`
+	UpdatedAt         string                     `json:"updatedAt"`
+}
+
+// ProductSlugResponse is a product found by slug. Moved is true when the slug was
+// an earlier one and clients should link to the current slug instead.
+type ProductSlugResponse struct {
+	ProductResponse
+	Moved bool `json:"moved"`
 }
 
 // ToProductResponse converts model to response DTO
@@ -34,14 +79,33 @@ func ToProductResponse(m *model.Product) ProductResponse {
 	if m == nil {
 		return ProductResponse{}
 	}
+	categoryIDs := make([]uint64, 0, len(m.Categories))
+	var primaryCategoryID *uint64
+	for _, link := range m.Categories {
+		categoryIDs = append(categoryIDs, link.CategoryID)
+		if link.IsPrimary {
+			id := link.CategoryID
+			primaryCategoryID = &id
+		}
+	}
+
 	return ProductResponse{
-		ID:               m.ID,
-		Name:             m.Name,
-		Description:      m.Description,
-		ShortDescription: m.ShortDescription,
-		Status:           m.Status,
-		CreatedAt:        m.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:        m.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:                m.ID,
+		Name:              m.Name,
+		Slug:              m.Slug,
+		Description:       m.Description,
+		ShortDescription:  m.ShortDescription,
+		Status:            m.Status,
+		MetaTitle:         m.MetaTitle,
+		MetaDescription:   m.MetaDescription,
+		CanonicalURL:      m.CanonicalURL,
+		BrandID:           m.BrandID,
+		Brand:             ToBrandSummaryResponse(m.Brand),
+		CategoryIDs:       categoryIDs,
+		PrimaryCategoryID: primaryCategoryID,
+		Attributes:        ToProductAttributeResponses(m.AttributeValues),
+		CreatedAt:         m.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:         m.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
 
@@ -53,3 +117,30 @@ func ToProductResponseList(list []model.Product) []ProductResponse {
 	}
 	return out
 }
+
+// ProductSearchResult is a product matching a search. Highlights are HTML-escaped
+// text with the matched words wrapped in <mark>.
+type ProductSearchResult struct {
+	ProductResponse
+	Rank       float64          `json:"rank"`
+	Highlights SearchHighlights `json:"highlights"`
+}
+
+// SearchHighlights holds the highlighted name and a description snippet
+type SearchHighlights struct {
+	Name    string `json:"name"`
+	Snippet string `json:"snippet"`
+}
+
+// ToProductSearchResults converts search hits to responses, keeping their order
+func ToProductSearchResults(hits []repository.ProductSearchHit) []ProductSearchResult {
+	out := make([]ProductSearchResult, 0, len(hits))
+	for i := range hits {
+		out = append(out, ProductSearchResult{
+			ProductResponse: ToProductResponse(&hits[i].Product),
+			Rank:            hits[i].Rank,
+			Highlights:      SearchHighlights{Name: hits[i].NameHighlight, Snippet: hits[i].Snippet},
+		})
+	}
+	return out
+}
@@ -0,0 +1,14 @@
+package dto
+
+// RoleRequest represents payload for creating or updating a role
+type RoleRequest struct {
+	Name        string   `json:"name" binding:"required,min=2,max=100"`
+	Description string   `json:"description,omitempty" binding:"max=255"`
+	RequireMFA  bool     `json:"requireMfa"`
+	Permissions []string `json:"permissions" binding:"dive,required"`
+}
+
+// AssignRoleRequest represents payload for assigning a role to a user
+type AssignRoleRequest struct {
+	RoleID uint64 `json:"roleId" binding:"required"`
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// Address is a saved shipping or billing address in a user's address book
+type Address struct {
+	ID                uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID            uint64    `json:"userId" gorm:"not null;index;uniqueIndex:idx_address_default_shipping,where:is_default_shipping;uniqueIndex:idx_address_default_billing,where:is_default_billing"` // at most one default of each kind per user
+	Label             string    `json:"label" gorm:"size:50"`                                                                                                                                             // e.g. Home, Work
+	FullName          string    `json:"fullName" gorm:"size:255;not null"`
+	Phone             string    `json:"phone" gorm:"size:20;not null"` // E.164
+	Line1             string    `json:"line1" gorm:"size:255;not null"`
+	Line2             string    `json:"line2" gorm:"size:255"`
+	Landmark          string    `json:"landmark" gorm:"size:255"`
+	City              string    `json:"city" gorm:"size:100;not null"`
+	State             string    `json:"state" gorm:"size:100;not null"`
+	Pincode           string    `json:"pincode" gorm:"size:6;not null"`
+	Country           string    `json:"country" gorm:"size:2;not null;default:'IN'"` // ISO 3166-1 alpha-2
+	IsDefaultShipping bool      `json:"isDefaultShipping" gorm:"not null;default:false"`
+	IsDefaultBilling  bool      `json:"isDefaultBilling" gorm:"not null;default:false"`
+	CreatedAt         time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type APIKey struct {
+	ID          uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string     `json:"name" gorm:"size:100;not null"`
+	Prefix      string     `json:"prefix" gorm:"size:16;not null;index"`     // visible part of the key, used to identify it
+	KeyHash     string     `json:"-" gorm:"size:64;uniqueIndex;not null"`    // SHA-256 of the full key
+	Scopes      []string   `json:"scopes" gorm:"serializer:json;type:jsonb"` // permission names granted to the key
+	CreatedByID *uint64    `json:"createdById" gorm:"index"`                 // admin who created the key
+	LastUsedAt  *time.Time `json:"lastUsedAt"`
+	ExpiresAt   *time.Time `json:"expiresAt"` // nullable, never expires when empty
+	RevokedAt   *time.Time `json:"revokedAt"`
+	CreatedAt   time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+}
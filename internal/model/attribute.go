@@ -0,0 +1,68 @@
+package model
+
+import "time"
+
+// Attribute value types
+const (
+	AttributeTypeText    = "text"
+	AttributeTypeNumber  = "number" // optionally with a unit such as cm or g
+	AttributeTypeBoolean = "boolean"
+	AttributeTypeEnum    = "enum" // one of the attribute's options
+)
+
+// Attribute is an admin-defined product specification such as Fabric or Weight
+type Attribute struct {
+	ID        uint64            `json:"id" gorm:"primaryKey;autoIncrement"`
+	Code      string            `json:"code" gorm:"size:100;not null;uniqueIndex"` // key used in product payloads, e.g. fabric
+	Name      string            `json:"name" gorm:"size:255;not null"`
+	Type      string            `json:"type" gorm:"size:20;not null"`
+	Unit      string            `json:"unit" gorm:"size:20"` // number attributes only
+	CreatedAt time.Time         `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt time.Time         `json:"updatedAt" gorm:"autoUpdateTime"`
+	Options   []AttributeOption `json:"options,omitempty" gorm:"foreignKey:AttributeID"`
+}
+
+// AttributeOption is an allowed value of an enum attribute
+type AttributeOption struct {
+	ID          uint64 `json:"id" gorm:"primaryKey;autoIncrement"`
+	AttributeID uint64 `json:"attributeId" gorm:"not null;uniqueIndex:idx_attribute_option_value,priority:1"`
+	Value       string `json:"value" gorm:"size:255;not null;uniqueIndex:idx_attribute_option_value,priority:2"`
+	Position    int    `json:"position" gorm:"default:0;not null"`
+}
+
+// CategoryAttribute assigns an attribute to a category and, through it, to every subcategory
+type CategoryAttribute struct {
+	CategoryID  uint64     `json:"categoryId" gorm:"primaryKey"`
+	AttributeID uint64     `json:"attributeId" gorm:"primaryKey;index"`
+	IsRequired  bool       `json:"isRequired" gorm:"default:false;not null"`
+	Position    int        `json:"position" gorm:"default:0;not null"`
+	Attribute   *Attribute `json:"attribute,omitempty" gorm:"foreignKey:AttributeID;constraint:OnDelete:CASCADE"`
+}
+
+// ProductAttributeValue is a product's value for one attribute, stored in the
+// column matching the attribute type so values can be filtered and compared
+type ProductAttributeValue struct {
+	ProductID   uint64           `json:"productId" gorm:"primaryKey"`
+	AttributeID uint64           `json:"attributeId" gorm:"primaryKey;index"`
+	TextValue   *string          `json:"textValue,omitempty" gorm:"size:1000"`
+	NumberValue *float64         `json:"numberValue,omitempty" gorm:"index"`
+	BoolValue   *bool            `json:"boolValue,omitempty"`
+	OptionID    *uint64          `json:"optionId,omitempty" gorm:"index"`
+	Attribute   *Attribute       `json:"attribute,omitempty" gorm:"foreignKey:AttributeID;constraint:OnDelete:CASCADE"`
+	Option      *AttributeOption `json:"option,omitempty" gorm:"foreignKey:OptionID;constraint:OnDelete:RESTRICT"`
+}
+
+// Value returns the stored value as a string, float64 or bool, or nil when unset
+func (v *ProductAttributeValue) Value() any {
+	switch {
+	case v.TextValue != nil:
+		return *v.TextValue
+	case v.NumberValue != nil:
+		return *v.NumberValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case v.Option != nil:
+		return v.Option.Value
+	}
+	return nil
+}
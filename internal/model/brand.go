@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// Brand is the maker or label a product is sold under
+type Brand struct {
+	ID          uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string    `json:"name" gorm:"size:255;not null"`
+	Slug        string    `json:"slug" gorm:"size:255;not null;default:'';uniqueIndex:idx_brand_slug,where:slug <> ''"`
+	Description string    `json:"description" gorm:"type:text"`
+	LogoMediaID *uint64   `json:"logoMediaId" gorm:"index"` // nullable, cleared when the media is deleted
+	CreatedAt   time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	// Relationships. The logo has no foreign key because media reference
+	// products, which reference brands.
+	Logo *Media `json:"logo,omitempty" gorm:"foreignKey:LogoMediaID;constraint:-"`
+}
@@ -4,14 +4,18 @@ import (
 	"time"
 )
 
-// JSONB represents a JSONB field for PostgreSQL
-
 type Category struct {
-	ID          uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name        string    `json:"name" gorm:"size:255;not null"`
-	Description string    `json:"description" gorm:"type:text"`
-	Depth       int       `json:"depth" gorm:"default:0;not null"` // 0 = main category, 1 = subcategory
-	ParentID    *uint64   `json:"parentId" gorm:"index"`           // nullable for root categories
-	CreatedAt   time.Time `json:"createdAt" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+	ID              uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name            string    `json:"name" gorm:"size:255;not null"`
+	Slug            string    `json:"slug" gorm:"size:255;not null;default:'';uniqueIndex:idx_category_slug,where:slug <> ''"`
+	Description     string    `json:"description" gorm:"type:text"`
+	MetaTitle       string    `json:"metaTitle" gorm:"size:255"`
+	MetaDescription string    `json:"metaDescription" gorm:"size:500"`
+	CanonicalURL    string    `json:"canonicalUrl" gorm:"size:500"`
+	Depth           int       `json:"depth" gorm:"default:0;not null"`           // 0 for root categories, parent depth + 1 below
+	ParentID        *uint64   `json:"parentId" gorm:"index"`                     // nullable for root categories
+	Path            string    `json:"path" gorm:"size:1024;not null;default:''"` // materialized path of ancestor IDs and own ID, e.g. /1/5/9/
+	Position        int       `json:"position" gorm:"default:0;not null"`        // sort order among siblings, ascending
+	CreatedAt       time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
 }
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// Scopes a login throttle counter can be kept for
+const (
+	ThrottleScopeAccount = "account" // keyed by normalized email or phone
+	ThrottleScopeIP      = "ip"      // keyed by client IP address
+)
+
+// LoginThrottle counts recent failed logins for an account or a client IP
+type LoginThrottle struct {
+	ID            uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Scope         string     `json:"scope" gorm:"size:16;not null;uniqueIndex:idx_login_throttle_scope_key"`
+	Key           string     `json:"key" gorm:"size:255;not null;uniqueIndex:idx_login_throttle_scope_key"`
+	Failures      int        `json:"failures" gorm:"not null;default:0"` // consecutive failures in the current window
+	LastFailureAt *time.Time `json:"lastFailureAt"`
+	BlockedUntil  *time.Time `json:"blockedUntil"` // exponential backoff, no attempt is evaluated before this
+	LockedUntil   *time.Time `json:"lockedUntil"`  // temporary lockout after too many failures
+	UpdatedAt     time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// LockoutEvent records every lockout for auditing
+type LockoutEvent struct {
+	ID           uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Scope        string     `json:"scope" gorm:"size:16;not null;index:idx_lockout_event_scope_key"`
+	Key          string     `json:"key" gorm:"size:255;not null;index:idx_lockout_event_scope_key"`
+	UserID       *uint64    `json:"userId" gorm:"index"` // set when the key belongs to a known account
+	IPAddress    string     `json:"ipAddress" gorm:"size:64"`
+	Failures     int        `json:"failures" gorm:"not null"`
+	LockedUntil  time.Time  `json:"lockedUntil" gorm:"not null"`
+	UnlockedAt   *time.Time `json:"unlockedAt"`   // set when an admin lifts the lockout early
+	UnlockedByID *uint64    `json:"unlockedById"` // admin who lifted the lockout
+	CreatedAt    time.Time  `json:"createdAt" gorm:"autoCreateTime;index"`
+}
@@ -5,7 +5,7 @@ import "time"
 type Media struct {
 	ID        uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
 	VariantID *uint64   `json:"variantId" gorm:"index"`            // nullable, for product variant specific media
-	ProductID uint64    `json:"productId" gorm:"not null;index"`   // required, media belongs to a product
+	ProductID *uint64   `json:"productId" gorm:"index"`            // nullable for media not tied to a product, such as brand logos
 	MediaType string    `json:"mediaType" gorm:"size:50;not null"` // image, video, etc.
 	URL       string    `json:"url" gorm:"size:500;not null"`      // media file URL
 	Alt       string    `json:"alt" gorm:"size:255"`               // alt text for accessibility
@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// Resource types that can carry metafields, each named after the owning table
+const (
+	MetafieldOwnerProduct  = "product"
+	MetafieldOwnerVariant  = "variant"
+	MetafieldOwnerCategory = "category"
+	MetafieldOwnerMedia    = "media"
+)
+
+// Metafield value types
+const (
+	MetafieldTypeText          = "single_line_text"
+	MetafieldTypeMultilineText = "multi_line_text"
+	MetafieldTypeInteger       = "integer"
+	MetafieldTypeDecimal       = "decimal"
+	MetafieldTypeBoolean       = "boolean"
+	MetafieldTypeDate          = "date" // YYYY-MM-DD
+	MetafieldTypeURL           = "url"
+	MetafieldTypeJSON          = "json" // any JSON object or array
+)
+
+// Metafield visibilities
+const (
+	MetafieldVisibilityPublic = "public" // returned by the storefront endpoints
+	MetafieldVisibilityAdmin  = "admin"  // only returned to catalog editors
+)
+
+// MetafieldDefinition declares a namespaced custom field, e.g. care.instructions on products
+type MetafieldDefinition struct {
+	ID          uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	OwnerType   string    `json:"ownerType" gorm:"size:32;not null;uniqueIndex:idx_metafield_definition_key,priority:1"`
+	Namespace   string    `json:"namespace" gorm:"size:50;not null;uniqueIndex:idx_metafield_definition_key,priority:2"`
+	Key         string    `json:"key" gorm:"size:50;not null;uniqueIndex:idx_metafield_definition_key,priority:3"`
+	Name        string    `json:"name" gorm:"size:255;not null"`
+	Description string    `json:"description" gorm:"size:500"`
+	Type        string    `json:"type" gorm:"size:32;not null"`
+	Visibility  string    `json:"visibility" gorm:"size:20;not null;default:'admin'"`
+	CreatedAt   time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// Metafield is the value of a definition for one resource
+type Metafield struct {
+	ID           uint64               `json:"id" gorm:"primaryKey;autoIncrement"`
+	DefinitionID uint64               `json:"definitionId" gorm:"not null;uniqueIndex:idx_metafield_owner_definition,priority:3"`
+	OwnerType    string               `json:"ownerType" gorm:"size:32;not null;uniqueIndex:idx_metafield_owner_definition,priority:1"`
+	OwnerID      uint64               `json:"ownerId" gorm:"not null;uniqueIndex:idx_metafield_owner_definition,priority:2"`
+	Value        any                  `json:"value" gorm:"serializer:json;type:jsonb;not null"`
+	CreatedAt    time.Time            `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time            `json:"updatedAt" gorm:"autoUpdateTime"`
+	Definition   *MetafieldDefinition `json:"definition,omitempty" gorm:"foreignKey:DefinitionID;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// OptionType is a dimension a product varies in, such as Size or Color
+type OptionType struct {
+	ID        uint64        `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProductID uint64        `json:"productId" gorm:"not null;uniqueIndex:idx_option_type_product_name,priority:1"`
+	Name      string        `json:"name" gorm:"size:100;not null;uniqueIndex:idx_option_type_product_name,priority:2"`
+	Position  int           `json:"position" gorm:"default:0;not null"` // order of the option on the product page and in SKUs
+	CreatedAt time.Time     `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt time.Time     `json:"updatedAt" gorm:"autoUpdateTime"`
+	Values    []OptionValue `json:"values" gorm:"foreignKey:OptionTypeID"`
+}
+
+// OptionValue is one choice of an option type, such as XL or Red
+type OptionValue struct {
+	ID           uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	OptionTypeID uint64    `json:"optionTypeId" gorm:"not null;uniqueIndex:idx_option_value_type_value,priority:1"`
+	Value        string    `json:"value" gorm:"size:100;not null;uniqueIndex:idx_option_value_type_value,priority:2"`
+	Code         string    `json:"code" gorm:"size:50;not null"` // short form used in generated SKUs, e.g. RED
+	Position     int       `json:"position" gorm:"default:0;not null"`
+	CreatedAt    time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// VariantOptionValue links a variant to its value for one option type.
+// The primary key allows a single value per option.
+type VariantOptionValue struct {
+	VariantID     uint64       `json:"variantId" gorm:"primaryKey"`
+	OptionTypeID  uint64       `json:"optionTypeId" gorm:"primaryKey"`
+	OptionValueID uint64       `json:"optionValueId" gorm:"not null;index"`
+	OptionValue   *OptionValue `json:"optionValue,omitempty" gorm:"foreignKey:OptionValueID;constraint:OnDelete:RESTRICT"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// PhoneOTP is a one-time login code sent by SMS
+type PhoneOTP struct {
+	ID         uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Phone      string     `json:"phone" gorm:"size:20;not null;index:idx_phone_otp_phone_created"` // E.164
+	CodeHash   string     `json:"-" gorm:"size:255;not null"`                                      // bcrypt hash of the code
+	ExpiresAt  time.Time  `json:"expiresAt" gorm:"not null"`
+	Attempts   int        `json:"attempts" gorm:"not null;default:0"` // wrong codes entered for this OTP
+	ConsumedAt *time.Time `json:"consumedAt"`                         // set once used, superseded or exhausted
+	IPAddress  string     `json:"ipAddress" gorm:"size:64"`           // client IP that requested the code
+	CreatedAt  time.Time  `json:"createdAt" gorm:"autoCreateTime;index:idx_phone_otp_phone_created"`
+}
@@ -5,9 +5,20 @@ import "time"
 type Product struct {
 	ID               uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
 	Name             string    `json:"name" gorm:"size:255;not null"`
+	Slug             string    `json:"slug" gorm:"size:255;not null;default:'';uniqueIndex:idx_product_slug,where:slug <> ''"`
 	Description      string    `json:"description"`
 	ShortDescription string    `json:"shortDescription"`
-	Status           string    `json:"status" gorm:"default:'active'"` // active, inactive
+	Status           string    `json:"status" gorm:"default:'active'"`  // active, inactive
+	MetaTitle        string    `json:"metaTitle" gorm:"size:255"`       // falls back to Name
+	MetaDescription  string    `json:"metaDescription" gorm:"size:500"` // falls back to ShortDescription
+	CanonicalURL     string    `json:"canonicalUrl" gorm:"size:500"`    // falls back to the storefront product URL
+	BrandID          *uint64   `json:"brandId" gorm:"index"`            // nullable, cleared when the brand is deleted
 	CreatedAt        time.Time `json:"createdAt" gorm:"autoCreateTime"`
 	UpdatedAt        time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+	// search_vector (tsvector) is maintained by database triggers and not mapped here
+
+	// Relationships
+	Brand           *Brand                  `json:"brand,omitempty" gorm:"foreignKey:BrandID;constraint:OnDelete:SET NULL"`
+	Categories      []ProductCategory       `json:"categories,omitempty" gorm:"foreignKey:ProductID"`
+	AttributeValues []ProductAttributeValue `json:"attributeValues,omitempty" gorm:"foreignKey:ProductID"`
 }
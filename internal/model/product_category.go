@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// ProductCategory assigns a product to a category
+type ProductCategory struct {
+	ProductID  uint64    `json:"productId" gorm:"primaryKey;uniqueIndex:idx_product_category_primary,where:is_primary"` // at most one primary category per product
+	CategoryID uint64    `json:"categoryId" gorm:"primaryKey;index"`
+	IsPrimary  bool      `json:"isPrimary" gorm:"not null;default:false"` // main category, used for breadcrumbs and canonical URLs
+	CreatedAt  time.Time `json:"createdAt" gorm:"autoCreateTime"`
+
+	// Relationships
+	Category *Category `json:"category,omitempty" gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+type RecoveryCode struct {
+	ID        uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    uint64     `json:"userId" gorm:"not null;index"`
+	CodeHash  string     `json:"-" gorm:"size:64;not null;index"` // SHA-256 of the normalized code
+	UsedAt    *time.Time `json:"usedAt"`
+	CreatedAt time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+type RefreshToken struct {
+	ID          uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID      uint64     `json:"userId" gorm:"not null;index"`
+	FamilyID    string     `json:"familyId" gorm:"size:36;not null;index"` // shared by every token rotated from the same login
+	TokenHash   string     `json:"-" gorm:"size:64;uniqueIndex;not null"`  // SHA-256 of the opaque token
+	ExpiresAt   time.Time  `json:"expiresAt" gorm:"not null"`              // absolute expiry of this token
+	RotatedAt   *time.Time `json:"rotatedAt"`                              // set once the token has been exchanged
+	RevokedAt   *time.Time `json:"revokedAt" gorm:"index"`                 // set on logout, reuse detection or admin revocation
+	MFAVerified bool       `json:"mfaVerified" gorm:"default:false"`       // session was opened with a second factor
+	IPAddress   string     `json:"ipAddress" gorm:"size:64"`               // client IP at issue time
+	UserAgent   string     `json:"userAgent" gorm:"size:500"`              // client user agent at issue time
+	CreatedAt   time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+
+	// Relationships
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
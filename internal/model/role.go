@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// Permission names enforced by the authorization middleware
+const (
+	PermCatalogWrite   = "catalog:write"   // products, categories and variants
+	PermInventoryWrite = "inventory:write" // stock levels and variant activation
+	PermMediaWrite     = "media:write"     // product and variant media
+	PermUsersManage    = "users:manage"    // roles, role assignments and sessions
+	PermAPIKeysManage  = "apikeys:manage"  // machine-to-machine API keys
+)
+
+// AllPermissions lists every permission known to the application
+var AllPermissions = map[string]string{
+	PermCatalogWrite:   "Create, update and delete products, categories and variants",
+	PermInventoryWrite: "Update stock and activate or deactivate variants",
+	PermMediaWrite:     "Create, update and delete media",
+	PermUsersManage:    "Manage roles, role assignments and user sessions",
+	PermAPIKeysManage:  "Create, list and revoke API keys",
+}
+
+type Permission struct {
+	ID          uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string    `json:"name" gorm:"size:100;uniqueIndex;not null"` // e.g. catalog:write
+	Description string    `json:"description" gorm:"size:255"`
+	CreatedAt   time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+type Role struct {
+	ID          uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string    `json:"name" gorm:"size:100;uniqueIndex;not null"`
+	Description string    `json:"description" gorm:"size:255"`
+	RequireMFA  bool      `json:"requireMfa" gorm:"default:false"` // members must sign in with two-factor authentication
+	CreatedAt   time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Permissions []Permission `json:"permissions,omitempty" gorm:"many2many:role_permission"`
+}
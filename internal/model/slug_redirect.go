@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Entity types that own slugs, each named after the owning table
+const (
+	SlugEntityProduct  = "product"
+	SlugEntityCategory = "category"
+	SlugEntityBrand    = "brand"
+)
+
+// SlugRedirect is a slug an entity used before, kept so old links still resolve
+type SlugRedirect struct {
+	ID         uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	EntityType string    `json:"entityType" gorm:"size:32;not null;uniqueIndex:idx_slug_redirect_entity_slug,priority:1;index:idx_slug_redirect_entity,priority:1"`
+	Slug       string    `json:"slug" gorm:"size:255;not null;uniqueIndex:idx_slug_redirect_entity_slug,priority:2"`
+	EntityID   uint64    `json:"entityId" gorm:"not null;index:idx_slug_redirect_entity,priority:2"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
@@ -1 +1,23 @@
 package model
+
+import "time"
+
+type User struct {
+	ID              uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Email           string     `json:"email" gorm:"size:255;not null;default:'';uniqueIndex:idx_user_email_address,where:email <> ''"` // empty for phone-only accounts
+	Phone           *string    `json:"phone" gorm:"size:20;uniqueIndex"`                                                               // E.164, nullable for email-only accounts
+	PasswordHash    string     `json:"-" gorm:"size:255;not null"`                                                                     // bcrypt hash, never serialized
+	Name            string     `json:"name" gorm:"size:255"`
+	IsActive        bool       `json:"isActive" gorm:"default:true"`
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt"` // nullable until the email address is confirmed
+	PhoneVerifiedAt *time.Time `json:"phoneVerifiedAt"` // set on the first successful OTP login
+	LastLoginAt     *time.Time `json:"lastLoginAt"`
+	TOTPSecret      string     `json:"-" gorm:"size:64"`   // base32 secret, set during enrollment
+	TOTPEnabledAt   *time.Time `json:"twoFactorEnabledAt"` // nullable until enrollment is confirmed
+	TOTPLastStep    int64      `json:"-" gorm:"default:0"` // last accepted time step, prevents code replay
+	CreatedAt       time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	// Relationships
+	Roles []Role `json:"roles,omitempty" gorm:"many2many:user_role"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Purposes of single-use user tokens
+const (
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposeEmailVerification = "email_verification"
+)
+
+type UserToken struct {
+	ID        uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    uint64     `json:"userId" gorm:"not null;index"`
+	Purpose   string     `json:"purpose" gorm:"size:50;not null;index"` // password_reset, email_verification
+	TokenHash string     `json:"-" gorm:"size:64;uniqueIndex;not null"` // SHA-256 of the emailed token
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"not null"`
+	UsedAt    *time.Time `json:"usedAt"` // set once the token has been consumed or superseded
+	CreatedAt time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+}
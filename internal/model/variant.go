@@ -6,15 +6,17 @@ import (
 
 type Variant struct {
 	ID            uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
-	ProductID     uint64    `json:"productId" gorm:"not null;index"`
+	ProductID     uint64    `json:"productId" gorm:"not null;index;uniqueIndex:idx_variant_product_option_key,priority:1,where:option_key <> ''"`
 	SKU           string    `json:"sku" gorm:"size:100;uniqueIndex;not null"`
 	Price         float64   `json:"price" gorm:"type:decimal(10,2);not null"`
 	StockQuantity int       `json:"stock_quantity" gorm:"default:0"`
 	IsActive      bool      `json:"isActive" gorm:"default:true"`
+	OptionKey     string    `json:"optionKey" gorm:"size:255;not null;default:'';uniqueIndex:idx_variant_product_option_key,priority:2"` // sorted option value IDs, e.g. 3-7, empty without options
 	CreatedAt     time.Time `json:"createdAt" gorm:"autoCreateTime"`
 	UpdatedAt     time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
 
 	// Relationships
-	Product *Product `json:"product,omitempty" gorm:"foreignKey:ProductID"`
-	Media   []Media  `json:"media,omitempty" gorm:"foreignKey:VariantID"`
+	Product      *Product             `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	Media        []Media              `json:"media,omitempty" gorm:"foreignKey:VariantID"`
+	OptionValues []VariantOptionValue `json:"optionValues,omitempty" gorm:"foreignKey:VariantID"`
 }
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AccountData struct {
+	User           *model.User
+	Roles          []model.Role
+	Addresses      []model.Address
+	Sessions       []model.RefreshToken
+	LoginCodes     []model.PhoneOTP
+	LockoutEvents  []model.LockoutEvent
+	APIKeysCreated []model.APIKey
+}
+
+// New tables keyed by user must be added to both Collect and Erase
+type AccountRepository interface {
+	Collect(userID uint64) (*AccountData, error)
+	Erase(userID uint64) error
+}
+
+type accountRepository struct {
+	db *gorm.DB
+}
+
+func NewAccountRepository(db *gorm.DB) AccountRepository {
+	return &accountRepository{db: db}
+}
+
+func (r *accountRepository) Collect(userID uint64) (*AccountData, error) {
+	var user model.User
+	if err := r.db.First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+
+	data := &AccountData{User: &user}
+	keys := accountKeys(&user)
+
+	err := r.db.Preload("Permissions").
+		Joins("JOIN user_role ON user_role.role_id = role.id").
+		Where("user_role.user_id = ?", userID).
+		Find(&data.Roles).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&data.Addresses).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&data.Sessions).Error; err != nil {
+		return nil, err
+	}
+	if user.Phone != nil {
+		if err := r.db.Where("phone = ?", *user.Phone).Order("created_at ASC").Find(&data.LoginCodes).Error; err != nil {
+			return nil, err
+		}
+	}
+	err = r.db.Where("user_id = ? OR (scope = ? AND key IN ?)", userID, model.ThrottleScopeAccount, keys).
+		Order("created_at ASC").
+		Find(&data.LockoutEvents).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("created_by_id = ?", userID).Order("created_at ASC").Find(&data.APIKeysCreated).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Erase deletes a user's personal data and anonymizes records kept for auditing
+func (r *accountRepository) Erase(userID uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, userID).Error; err != nil {
+			return err
+		}
+		keys := accountKeys(&user)
+
+		// Kept, anonymized: lockout events and the user's API keys, revoked. Orders belong here once stored.
+		err := tx.Model(&model.LockoutEvent{}).
+			Where("user_id = ? OR (scope = ? AND key IN ?)", userID, model.ThrottleScopeAccount, keys).
+			Updates(map[string]interface{}{
+				"user_id":    nil,
+				"key":        fmt.Sprintf("erased-user-%d", userID),
+				"ip_address": "",
+			}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&model.LockoutEvent{}).Where("unlocked_by_id = ?", userID).Update("unlocked_by_id", nil).Error; err != nil {
+			return err
+		}
+		err = tx.Model(&model.APIKey{}).
+			Where("created_by_id = ?", userID).
+			Updates(map[string]interface{}{
+				"created_by_id": nil,
+				"revoked_at":    gorm.Expr("COALESCE(revoked_at, ?)", time.Now()),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		// Deleted
+		deletes := []struct {
+			model interface{}
+			query string
+			args  []interface{}
+		}{
+			{&model.Address{}, "user_id = ?", []interface{}{userID}},
+			{&model.RefreshToken{}, "user_id = ?", []interface{}{userID}},
+			{&model.UserToken{}, "user_id = ?", []interface{}{userID}},
+			{&model.RecoveryCode{}, "user_id = ?", []interface{}{userID}},
+			{&model.LoginThrottle{}, "scope = ? AND key IN ?", []interface{}{model.ThrottleScopeAccount, keys}},
+		}
+		for _, d := range deletes {
+			if err := tx.Where(d.query, d.args...).Delete(d.model).Error; err != nil {
+				return err
+			}
+		}
+		if user.Phone != nil {
+			if err := tx.Where("phone = ?", *user.Phone).Delete(&model.PhoneOTP{}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&user).Association("Roles").Clear(); err != nil {
+			return err
+		}
+		return tx.Delete(&user).Error
+	})
+}
+
+func accountKeys(user *model.User) []string {
+	var keys []string
+	if user.Email != "" {
+		keys = append(keys, user.Email)
+	}
+	if user.Phone != nil {
+		keys = append(keys, *user.Phone)
+	}
+	return keys
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type AddressRepository interface {
+	Save(address *model.Address) error
+	GetByUser(userID uint64) ([]model.Address, error)
+	GetForUser(id, userID uint64) (*model.Address, error)
+	CountByUser(userID uint64) (int64, error)
+	Delete(id, userID uint64) error
+}
+
+type addressRepository struct {
+	db *gorm.DB
+}
+
+func NewAddressRepository(db *gorm.DB) AddressRepository {
+	return &addressRepository{db: db}
+}
+
+// Save creates or updates an address, clearing a default flag it sets on the user's other addresses
+func (r *addressRepository) Save(address *model.Address) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		others := tx.Model(&model.Address{}).Where("user_id = ? AND id <> ?", address.UserID, address.ID)
+		if address.IsDefaultShipping {
+			if err := others.Session(&gorm.Session{}).Update("is_default_shipping", false).Error; err != nil {
+				return err
+			}
+		}
+		if address.IsDefaultBilling {
+			if err := others.Session(&gorm.Session{}).Update("is_default_billing", false).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(address).Error
+	})
+}
+
+func (r *addressRepository) GetByUser(userID uint64) ([]model.Address, error) {
+	var addresses []model.Address
+	err := r.db.Where("user_id = ?", userID).
+		Order("is_default_shipping DESC, is_default_billing DESC, created_at DESC").
+		Find(&addresses).Error
+	return addresses, err
+}
+
+func (r *addressRepository) GetForUser(id, userID uint64) (*model.Address, error) {
+	var address model.Address
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&address).Error
+	if err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+func (r *addressRepository) CountByUser(userID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Address{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Delete removes an address; the most recent remaining one inherits its default flags
+func (r *addressRepository) Delete(id, userID uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var address model.Address
+		if err := tx.Where("id = ? AND user_id = ?", id, userID).First(&address).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&address).Error; err != nil {
+			return err
+		}
+		if !address.IsDefaultShipping && !address.IsDefaultBilling {
+			return nil
+		}
+
+		var next model.Address
+		err := tx.Where("user_id = ?", userID).Order("created_at DESC").First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if address.IsDefaultShipping {
+			next.IsDefaultShipping = true
+		}
+		if address.IsDefaultBilling {
+			next.IsDefaultBilling = true
+		}
+		return tx.Save(&next).Error
+	})
+}
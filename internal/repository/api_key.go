@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	Create(key *model.APIKey) error
+	GetByID(id uint64) (*model.APIKey, error)
+	GetByHash(keyHash string) (*model.APIKey, error)
+	GetAll() ([]model.APIKey, error)
+	Revoke(id uint64) error
+	TouchLastUsed(id uint64, at time.Time) error
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) Create(key *model.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+func (r *apiKeyRepository) GetByID(id uint64) (*model.APIKey, error) {
+	var key model.APIKey
+	err := r.db.First(&key, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) GetByHash(keyHash string) (*model.APIKey, error) {
+	var key model.APIKey
+	err := r.db.Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) GetAll() ([]model.APIKey, error) {
+	var keys []model.APIKey
+	err := r.db.Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *apiKeyRepository) Revoke(id uint64) error {
+	return r.db.Model(&model.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+// TouchLastUsed records usage without bumping updated_at
+func (r *apiKeyRepository) TouchLastUsed(id uint64, at time.Time) error {
+	return r.db.Model(&model.APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", at).Error
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type AttributeRepository interface {
+	Create(attribute *model.Attribute) error
+	GetByID(id uint64) (*model.Attribute, error)
+	GetByCode(code string) (*model.Attribute, error)
+	GetByCodes(codes []string) ([]model.Attribute, error)
+	GetAll() ([]model.Attribute, error)
+	Update(attribute *model.Attribute, options []model.AttributeOption) error
+	Delete(id uint64) error
+	CountValuesUsingOptions(optionIDs []uint64) (int64, error)
+	GetCategoryAttributes(categoryIDs []uint64) ([]model.CategoryAttribute, error)
+	SetCategoryAttributes(categoryID uint64, links []model.CategoryAttribute) error
+	SetProductValues(productID uint64, values []model.ProductAttributeValue) error
+}
+
+type attributeRepository struct {
+	db *gorm.DB
+}
+
+func NewAttributeRepository(db *gorm.DB) AttributeRepository {
+	return &attributeRepository{db: db}
+}
+
+func (r *attributeRepository) Create(attribute *model.Attribute) error {
+	return r.db.Create(attribute).Error
+}
+
+func (r *attributeRepository) GetByID(id uint64) (*model.Attribute, error) {
+	var attribute model.Attribute
+	err := r.db.Preload("Options", byPosition).First(&attribute, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attribute, nil
+}
+
+func (r *attributeRepository) GetByCode(code string) (*model.Attribute, error) {
+	var attribute model.Attribute
+	err := r.db.Preload("Options", byPosition).Where("code = ?", code).First(&attribute).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attribute, nil
+}
+
+func (r *attributeRepository) GetByCodes(codes []string) ([]model.Attribute, error) {
+	var attributes []model.Attribute
+	if len(codes) == 0 {
+		return attributes, nil
+	}
+	err := r.db.Preload("Options", byPosition).Where("code IN ?", codes).Find(&attributes).Error
+	return attributes, err
+}
+
+func (r *attributeRepository) GetAll() ([]model.Attribute, error) {
+	var attributes []model.Attribute
+	err := r.db.Preload("Options", byPosition).Order("name ASC").Find(&attributes).Error
+	return attributes, err
+}
+
+// Update keeps options with an ID, inserts the rest and deletes those no longer listed
+func (r *attributeRepository) Update(attribute *model.Attribute, options []model.AttributeOption) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(attribute).Select("name", "unit").Updates(attribute).Error; err != nil {
+			return err
+		}
+
+		keep := make([]uint64, 0, len(options))
+		for _, option := range options {
+			if option.ID != 0 {
+				keep = append(keep, option.ID)
+			}
+		}
+		stale := tx.Where("attribute_id = ?", attribute.ID)
+		if len(keep) > 0 {
+			stale = stale.Where("id NOT IN ?", keep)
+		}
+		if err := stale.Delete(&model.AttributeOption{}).Error; err != nil {
+			return err
+		}
+
+		for i := range options {
+			options[i].AttributeID = attribute.ID
+			if options[i].ID != 0 {
+				err := tx.Model(&options[i]).Update("position", options[i].Position).Error
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if err := tx.Create(&options[i]).Error; err != nil {
+				return err
+			}
+		}
+		attribute.Options = options
+		return nil
+	})
+}
+
+func (r *attributeRepository) Delete(id uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("attribute_id = ?", id).Delete(&model.ProductAttributeValue{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("attribute_id = ?", id).Delete(&model.CategoryAttribute{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("attribute_id = ?", id).Delete(&model.AttributeOption{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Attribute{}, id).Error
+	})
+}
+
+func (r *attributeRepository) CountValuesUsingOptions(optionIDs []uint64) (int64, error) {
+	var count int64
+	if len(optionIDs) == 0 {
+		return 0, nil
+	}
+	err := r.db.Model(&model.ProductAttributeValue{}).Where("option_id IN ?", optionIDs).Count(&count).Error
+	return count, err
+}
+
+func (r *attributeRepository) GetCategoryAttributes(categoryIDs []uint64) ([]model.CategoryAttribute, error) {
+	var links []model.CategoryAttribute
+	if len(categoryIDs) == 0 {
+		return links, nil
+	}
+	err := r.db.Preload("Attribute.Options", byPosition).
+		Where("category_id IN ?", categoryIDs).
+		Order("position ASC, attribute_id ASC").
+		Find(&links).Error
+	return links, err
+}
+
+func (r *attributeRepository) SetCategoryAttributes(categoryID uint64, links []model.CategoryAttribute) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("category_id = ?", categoryID).Delete(&model.CategoryAttribute{}).Error; err != nil {
+			return err
+		}
+		for i := range links {
+			links[i].CategoryID = categoryID
+		}
+		if len(links) == 0 {
+			return nil
+		}
+		return tx.Omit("Attribute").Create(&links).Error
+	})
+}
+
+func (r *attributeRepository) SetProductValues(productID uint64, values []model.ProductAttributeValue) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", productID).Delete(&model.ProductAttributeValue{}).Error; err != nil {
+			return err
+		}
+		for i := range values {
+			values[i].ProductID = productID
+		}
+		if len(values) == 0 {
+			return nil
+		}
+		return tx.Omit("Attribute", "Option").Create(&values).Error
+	})
+}
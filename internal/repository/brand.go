@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BrandRepository interface {
+	Create(brand *model.Brand) error
+	GetByID(id uint64) (*model.Brand, error)
+	GetBySlug(slug string) (*model.Brand, error)
+	GetAllWithPagination(page, limit int) ([]model.Brand, int64, error)
+	Update(brand *model.Brand) error
+	Delete(id uint64) error
+}
+
+type brandRepository struct {
+	db *gorm.DB
+}
+
+func NewBrandRepository(db *gorm.DB) BrandRepository {
+	return &brandRepository{db: db}
+}
+
+func (r *brandRepository) Create(brand *model.Brand) error {
+	return r.db.Omit("Logo").Create(brand).Error
+}
+
+func (r *brandRepository) GetByID(id uint64) (*model.Brand, error) {
+	var brand model.Brand
+	if err := r.db.Preload("Logo").First(&brand, id).Error; err != nil {
+		return nil, err
+	}
+	return &brand, nil
+}
+
+func (r *brandRepository) GetBySlug(slug string) (*model.Brand, error) {
+	var brand model.Brand
+	if err := r.db.Preload("Logo").Where("slug = ?", slug).First(&brand).Error; err != nil {
+		return nil, err
+	}
+	return &brand, nil
+}
+
+func (r *brandRepository) GetAllWithPagination(page, limit int) ([]model.Brand, int64, error) {
+	var brands []model.Brand
+	var total int64
+
+	// Get total count
+	if err := r.db.Model(&model.Brand{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Calculate offset
+	offset := (page - 1) * limit
+
+	// Fetch paginated results
+	err := r.db.Preload("Logo").Order("name ASC, id ASC").Offset(offset).Limit(limit).Find(&brands).Error
+	return brands, total, err
+}
+
+func (r *brandRepository) Update(brand *model.Brand) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current model.Brand
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "slug").First(&current, brand.ID).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(brand).Select("name", "slug", "description", "logo_media_id").Updates(brand).Error; err != nil {
+			return err
+		}
+		return recordSlugChange(tx, model.SlugEntityBrand, brand.ID, current.Slug, brand.Slug)
+	})
+}
+
+// Delete keeps the brand's products, without a brand
+func (r *brandRepository) Delete(id uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteSlugRedirects(tx, model.SlugEntityBrand, id); err != nil {
+			return err
+		}
+		return tx.Delete(&model.Brand{}, id).Error
+	})
+}
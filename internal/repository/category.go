@@ -1,13 +1,29 @@
 package repository
 
 import (
+	"errors"
+	"strconv"
+	"strings"
+
 	"github.com/Durgarao310/zneha-backend/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var ErrCategoryCycle = errors.New("category cannot be moved into its own subtree")
+
+var ErrSiblingSetMismatch = errors.New("ids must list every sibling exactly once")
+
+// ProductCount includes active products of every subcategory
+type CategoryWithProductCount struct {
+	model.Category
+	ProductCount int64 `json:"productCount"`
+}
+
 type CategoryRepository interface {
 	Create(category *model.Category) error
 	GetByID(id uint64) (*model.Category, error)
+	GetBySlug(slug string) (*model.Category, error)
 	GetAll() ([]model.Category, error)
 	GetByParentID(parentID *uint64) ([]model.Category, error)
 	GetMainCategories() ([]model.Category, error)
@@ -15,6 +31,13 @@ type CategoryRepository interface {
 	Delete(id uint64) error
 	GetAllWithPagination(page, limit int) ([]model.Category, int64, error)
 	GetByParentIDWithPagination(parentID *uint64, page, limit int) ([]model.Category, int64, error)
+	GetDescendantIDs(id uint64) ([]uint64, error)
+	CountByIDs(ids []uint64) (int64, error)
+	GetAncestors(category *model.Category) ([]model.Category, error)
+	GetDescendants(category *model.Category, maxDepth int) ([]model.Category, error)
+	MoveSubtree(id uint64, newParentID *uint64) (*model.Category, error)
+	GetTree(root *model.Category, maxDepth int) ([]CategoryWithProductCount, error)
+	ReorderSiblings(parentID *uint64, ids []uint64) error
 }
 
 type categoryRepository struct {
@@ -26,7 +49,32 @@ func NewCategoryRepository(db *gorm.DB) CategoryRepository {
 }
 
 func (r *categoryRepository) Create(category *model.Category) error {
-	return r.db.Create(category).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		parentPath := "/"
+		category.Depth = 0
+		if category.ParentID != nil {
+			var parent model.Category
+			if err := tx.Clauses(clause.Locking{Strength: "SHARE"}).First(&parent, *category.ParentID).Error; err != nil {
+				return err
+			}
+			parentPath = parent.Path
+			category.Depth = parent.Depth + 1
+		}
+
+		position, err := nextPosition(tx, category.ParentID)
+		if err != nil {
+			return err
+		}
+		category.Position = position
+
+		category.Path = ""
+		if err := tx.Create(category).Error; err != nil {
+			return err
+		}
+
+		category.Path = parentPath + strconv.FormatUint(category.ID, 10) + "/"
+		return tx.Model(category).Update("path", category.Path).Error
+	})
 }
 
 func (r *categoryRepository) GetByID(id uint64) (*model.Category, error) {
@@ -35,6 +83,15 @@ func (r *categoryRepository) GetByID(id uint64) (*model.Category, error) {
 	return &category, err
 }
 
+func (r *categoryRepository) GetBySlug(slug string) (*model.Category, error) {
+	var category model.Category
+	err := r.db.Where("slug = ?", slug).First(&category).Error
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
 func (r *categoryRepository) GetAll() ([]model.Category, error) {
 	var categories []model.Category
 	err := r.db.Find(&categories).Error
@@ -43,22 +100,45 @@ func (r *categoryRepository) GetAll() ([]model.Category, error) {
 
 func (r *categoryRepository) GetByParentID(parentID *uint64) ([]model.Category, error) {
 	var categories []model.Category
-	err := r.db.Where("parent_id = ?", parentID).Find(&categories).Error
+	err := r.db.Where("parent_id = ?", parentID).Order("position ASC, id ASC").Find(&categories).Error
 	return categories, err
 }
 
 func (r *categoryRepository) GetMainCategories() ([]model.Category, error) {
 	var categories []model.Category
-	err := r.db.Where("parent_id IS NULL").Find(&categories).Error
+	err := r.db.Where("parent_id IS NULL").Order("position ASC, id ASC").Find(&categories).Error
 	return categories, err
 }
 
+// Update leaves the tree position to MoveSubtree
 func (r *categoryRepository) Update(category *model.Category) error {
-	return r.db.Save(category).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current model.Category
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "slug").First(&current, category.ID).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(category).Select("name", "slug", "description", "meta_title", "meta_description", "canonical_url").Updates(category).Error; err != nil {
+			return err
+		}
+		return recordSlugChange(tx, model.SlugEntityCategory, category.ID, current.Slug, category.Slug)
+	})
 }
 
+// Delete removes the category with everything keyed by it
 func (r *categoryRepository) Delete(id uint64) error {
-	return r.db.Delete(&model.Category{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteSlugRedirects(tx, model.SlugEntityCategory, id); err != nil {
+			return err
+		}
+		if err := deleteMetafields(tx, model.MetafieldOwnerCategory, id); err != nil {
+			return err
+		}
+		if err := tx.Where("category_id = ?", id).Delete(&model.CategoryAttribute{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Category{}, id).Error
+	})
 }
 
 func (r *categoryRepository) GetAllWithPagination(page, limit int) ([]model.Category, int64, error) {
@@ -93,6 +173,212 @@ func (r *categoryRepository) GetByParentIDWithPagination(parentID *uint64, page,
 	offset := (page - 1) * limit
 
 	// Fetch paginated results
-	err := query.Offset(offset).Limit(limit).Find(&categories).Error
+	err := query.Order("position ASC, id ASC").Offset(offset).Limit(limit).Find(&categories).Error
 	return categories, total, err
 }
+
+func (r *categoryRepository) GetDescendantIDs(id uint64) ([]uint64, error) {
+	category, err := r.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uint64
+	err = r.db.Model(&model.Category{}).
+		Where("path LIKE ?", category.Path+"%").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *categoryRepository) CountByIDs(ids []uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Category{}).Where("id IN ?", ids).Count(&count).Error
+	return count, err
+}
+
+func (r *categoryRepository) GetAncestors(category *model.Category) ([]model.Category, error) {
+	ids := pathIDs(category.Path)
+	if len(ids) <= 1 {
+		return []model.Category{}, nil
+	}
+
+	var ancestors []model.Category
+	err := r.db.Where("id IN ?", ids[:len(ids)-1]).Order("depth ASC").Find(&ancestors).Error
+	return ancestors, err
+}
+
+// A positive maxDepth limits how many levels are returned
+func (r *categoryRepository) GetDescendants(category *model.Category, maxDepth int) ([]model.Category, error) {
+	query := r.db.Where("path LIKE ? AND id <> ?", category.Path+"%", category.ID)
+	if maxDepth > 0 {
+		query = query.Where("depth <= ?", category.Depth+maxDepth)
+	}
+
+	var descendants []model.Category
+	err := query.Order("depth ASC, position ASC, id ASC").Find(&descendants).Error
+	return descendants, err
+}
+
+// MoveSubtree rewrites the path and depth of the whole subtree; a nil newParentID makes it a root
+func (r *categoryRepository) MoveSubtree(id uint64, newParentID *uint64) (*model.Category, error) {
+	var moved model.Category
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&moved, id).Error; err != nil {
+			return err
+		}
+
+		newParentPath := "/"
+		newDepth := 0
+		if newParentID != nil {
+			var parent model.Category
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&parent, *newParentID).Error; err != nil {
+				return err
+			}
+			if strings.HasPrefix(parent.Path, moved.Path) {
+				return ErrCategoryCycle
+			}
+			newParentPath = parent.Path
+			newDepth = parent.Depth + 1
+		}
+
+		oldPath := moved.Path
+		newPath := newParentPath + strconv.FormatUint(moved.ID, 10) + "/"
+		depthDelta := newDepth - moved.Depth
+
+		err := tx.Model(&model.Category{}).
+			Where("path LIKE ?", oldPath+"%").
+			Updates(map[string]interface{}{
+				"path":  gorm.Expr("? || substr(path, ?)", newPath, len(oldPath)+1),
+				"depth": gorm.Expr("depth + ?", depthDelta),
+			}).Error
+		if err != nil {
+			return err
+		}
+		position := moved.Position
+		if !SameParentID(moved.ParentID, newParentID) {
+			if position, err = nextPosition(tx, newParentID); err != nil {
+				return err
+			}
+		}
+		err = tx.Model(&moved).Updates(map[string]interface{}{"parent_id": newParentID, "position": position}).Error
+		if err != nil {
+			return err
+		}
+
+		moved.ParentID = newParentID
+		moved.Position = position
+		moved.Path = newPath
+		moved.Depth = newDepth
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &moved, nil
+}
+
+// GetTree returns parents before their children. A positive maxDepth limits the
+// levels, counting the top level as one.
+func (r *categoryRepository) GetTree(root *model.Category, maxDepth int) ([]CategoryWithProductCount, error) {
+	counts := r.db.Table("product_category pc").
+		Select("a.id::bigint AS category_id, COUNT(DISTINCT pc.product_id) AS product_count").
+		Joins("JOIN product p ON p.id = pc.product_id").
+		Joins("JOIN category d ON d.id = pc.category_id").
+		Joins("CROSS JOIN LATERAL unnest(string_to_array(trim(both '/' from d.path), '/')) AS a(id)").
+		Where("p.status = ?", "active").
+		Group("a.id")
+
+	query := r.db.Table("category AS c").
+		Select("c.*, COALESCE(counts.product_count, 0) AS product_count")
+
+	topDepth := 0
+	if root != nil {
+		counts = counts.Where("d.path LIKE ?", root.Path+"%")
+		query = query.Where("c.path LIKE ?", root.Path+"%")
+		topDepth = root.Depth
+	}
+	if maxDepth > 0 {
+		query = query.Where("c.depth < ?", topDepth+maxDepth)
+	}
+
+	var rows []CategoryWithProductCount
+	err := query.Joins("LEFT JOIN (?) AS counts ON counts.category_id = c.id", counts).
+		Order("c.depth ASC, c.position ASC, c.id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// ReorderSiblings requires ids to name every child of parentID exactly once
+func (r *categoryRepository) ReorderSiblings(parentID *uint64, ids []uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var siblingIDs []uint64
+		err := siblings(tx, parentID).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Pluck("id", &siblingIDs).Error
+		if err != nil {
+			return err
+		}
+
+		if !sameIDSet(siblingIDs, ids) {
+			return ErrSiblingSetMismatch
+		}
+
+		for position, id := range ids {
+			if err := tx.Model(&model.Category{}).Where("id = ?", id).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func siblings(tx *gorm.DB, parentID *uint64) *gorm.DB {
+	query := tx.Model(&model.Category{})
+	if parentID == nil {
+		return query.Where("parent_id IS NULL")
+	}
+	return query.Where("parent_id = ?", *parentID)
+}
+
+func nextPosition(tx *gorm.DB, parentID *uint64) (int, error) {
+	var position int
+	err := siblings(tx, parentID).Select("COALESCE(MAX(position), -1) + 1").Scan(&position).Error
+	return position, err
+}
+
+func sameIDSet(want, ids []uint64) bool {
+	if len(want) != len(ids) {
+		return false
+	}
+	known := make(map[uint64]bool, len(want))
+	for _, id := range want {
+		known[id] = true
+	}
+	for _, id := range ids {
+		if !known[id] {
+			return false
+		}
+		delete(known, id) // a repeated ID fails on its second occurrence
+	}
+	return true
+}
+
+// SameParentID compares two optional parent IDs
+func SameParentID(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// pathIDs parses a path such as /1/5/9/
+func pathIDs(path string) []uint64 {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	ids := make([]uint64, 0, len(parts))
+	for _, part := range parts {
+		if id, err := strconv.ParseUint(part, 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoginThrottleRepository interface {
+	Get(scope, key string) (*model.LoginThrottle, error)
+	IncrementFailures(scope, key string, windowStart time.Time) (*model.LoginThrottle, error)
+	SetBlocks(id uint64, blockedUntil, lockedUntil *time.Time) error
+	Reset(scope, key string) error
+	CreateEvent(event *model.LockoutEvent) error
+	ListEvents(activeOnly bool, page, limit int) ([]model.LockoutEvent, int64, error)
+	MarkEventsUnlocked(scope, key string, unlockedByID uint64) error
+}
+
+type loginThrottleRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginThrottleRepository(db *gorm.DB) LoginThrottleRepository {
+	return &loginThrottleRepository{db: db}
+}
+
+func (r *loginThrottleRepository) Get(scope, key string) (*model.LoginThrottle, error) {
+	var throttle model.LoginThrottle
+	err := r.db.Where("scope = ? AND key = ?", scope, key).First(&throttle).Error
+	if err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+// IncrementFailures counts a failure, restarting at one when the last is older than windowStart
+func (r *loginThrottleRepository) IncrementFailures(scope, key string, windowStart time.Time) (*model.LoginThrottle, error) {
+	now := time.Now()
+	throttle := model.LoginThrottle{
+		Scope:         scope,
+		Key:           key,
+		Failures:      1,
+		LastFailureAt: &now,
+	}
+
+	err := r.db.Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{{Name: "scope"}, {Name: "key"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"failures": gorm.Expr(
+					"CASE WHEN login_throttle.last_failure_at < ? THEN 1 ELSE login_throttle.failures + 1 END",
+					windowStart,
+				),
+				"last_failure_at": now,
+				"updated_at":      now,
+			}),
+		},
+		clause.Returning{},
+	).Create(&throttle).Error
+	if err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+func (r *loginThrottleRepository) SetBlocks(id uint64, blockedUntil, lockedUntil *time.Time) error {
+	return r.db.Model(&model.LoginThrottle{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"blocked_until": blockedUntil,
+			"locked_until":  lockedUntil,
+		}).Error
+}
+
+func (r *loginThrottleRepository) Reset(scope, key string) error {
+	return r.db.Where("scope = ? AND key = ?", scope, key).Delete(&model.LoginThrottle{}).Error
+}
+
+func (r *loginThrottleRepository) CreateEvent(event *model.LockoutEvent) error {
+	return r.db.Create(event).Error
+}
+
+func (r *loginThrottleRepository) ListEvents(activeOnly bool, page, limit int) ([]model.LockoutEvent, int64, error) {
+	var events []model.LockoutEvent
+	var total int64
+
+	query := r.db.Model(&model.LockoutEvent{})
+	if activeOnly {
+		query = query.Where("unlocked_at IS NULL AND locked_until > ?", time.Now())
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&events).Error
+	return events, total, err
+}
+
+func (r *loginThrottleRepository) MarkEventsUnlocked(scope, key string, unlockedByID uint64) error {
+	return r.db.Model(&model.LockoutEvent{}).
+		Where("scope = ? AND key = ? AND unlocked_at IS NULL AND locked_until > ?", scope, key, time.Now()).
+		Updates(map[string]interface{}{
+			"unlocked_at":    time.Now(),
+			"unlocked_by_id": unlockedByID,
+		}).Error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
 	"gorm.io/gorm"
 )
 
@@ -10,7 +11,7 @@ type MediaRepository interface {
 	GetByID(id uint64) (*model.Media, error)
 	GetByProductID(productID uint64) ([]model.Media, error)
 	GetByVariantID(variantID uint64) ([]model.Media, error)
-	GetByProductIDWithPagination(productID uint64, page, limit int) ([]model.Media, int64, error)
+	GetByProductIDWithPagination(productID uint64, spec pagination.QuerySpec, params pagination.PaginationParams) ([]model.Media, pagination.PageInfo, error)
 	GetByVariantIDWithPagination(variantID uint64, page, limit int) ([]model.Media, int64, error)
 	GetPrimaryByProductID(productID uint64) (*model.Media, error)
 	Update(media *model.Media) error
@@ -18,6 +19,17 @@ type MediaRepository interface {
 	SetPrimary(productID uint64, mediaID uint64) error
 }
 
+// mediaQueryFields are the media fields clients may sort and filter by
+var mediaQueryFields = pagination.Fields{
+	"id":        {Column: "id", Type: pagination.FieldInteger},
+	"variantId": {Column: "variant_id", Type: pagination.FieldInteger, Nullable: true},
+	"mediaType": {Column: "media_type", Type: pagination.FieldString},
+	"position":  {Column: "position", Type: pagination.FieldInteger},
+	"isPrimary": {Column: "is_primary", Type: pagination.FieldBoolean},
+	"createdAt": {Column: "created_at", Type: pagination.FieldTime},
+	"updatedAt": {Column: "updated_at", Type: pagination.FieldTime},
+}
+
 type mediaRepository struct {
 	db *gorm.DB
 }
@@ -42,24 +54,22 @@ func (r *mediaRepository) GetByProductID(productID uint64) ([]model.Media, error
 	return media, err
 }
 
-func (r *mediaRepository) GetByProductIDWithPagination(productID uint64, page, limit int) ([]model.Media, int64, error) {
+// GetByProductIDWithPagination keeps gallery order unless the request sorts otherwise
+func (r *mediaRepository) GetByProductIDWithPagination(productID uint64, spec pagination.QuerySpec, params pagination.PaginationParams) ([]model.Media, pagination.PageInfo, error) {
 	var media []model.Media
-	var total int64
-
-	// Get total count for this product
-	if err := r.db.Model(&model.Media{}).Where("product_id = ?", productID).Count(&total).Error; err != nil {
-		return nil, 0, err
+	filtered := func(db *gorm.DB) *gorm.DB {
+		return db.Model(&model.Media{}).
+			Where("product_id = ?", productID).
+			Scopes(withFilters("media", mediaQueryFields, spec.Filters))
 	}
-
-	// Get paginated results using database-level pagination
-	offset := (page - 1) * limit
-	err := r.db.Where("product_id = ?", productID).
-		Order("position ASC").
-		Limit(limit).
-		Offset(offset).
-		Find(&media).Error
-
-	return media, total, err
+	query := pageQuery{
+		table:    "media",
+		fields:   mediaQueryFields,
+		sort:     spec.Sort,
+		defaults: []pagination.Sort{{Field: "position"}},
+	}
+	info, err := findPage(r.db, filtered, query, params, &media)
+	return media, info, err
 }
 
 func (r *mediaRepository) GetByVariantID(variantID uint64) ([]model.Media, error) {
@@ -98,8 +108,17 @@ func (r *mediaRepository) Update(media *model.Media) error {
 	return r.db.Save(media).Error
 }
 
+// Delete also unsets the media as a brand logo
 func (r *mediaRepository) Delete(id uint64) error {
-	return r.db.Delete(&model.Media{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteMetafields(tx, model.MetafieldOwnerMedia, id); err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Brand{}).Where("logo_media_id = ?", id).Update("logo_media_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Media{}, id).Error
+	})
 }
 
 func (r *mediaRepository) SetPrimary(productID uint64, mediaID uint64) error {
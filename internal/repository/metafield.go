@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MetafieldRepository interface {
+	CreateDefinition(definition *model.MetafieldDefinition) error
+	GetDefinition(id uint64) (*model.MetafieldDefinition, error)
+	FindDefinition(ownerType, namespace, key string) (*model.MetafieldDefinition, error)
+	GetDefinitions(ownerType string) ([]model.MetafieldDefinition, error)
+	UpdateDefinition(definition *model.MetafieldDefinition) error
+	DeleteDefinition(id uint64) error
+	GetByOwner(ownerType string, ownerID uint64, publicOnly bool) ([]model.Metafield, error)
+	Upsert(metafield *model.Metafield) error
+	Delete(ownerType string, ownerID, definitionID uint64) (bool, error)
+	OwnerExists(ownerType string, ownerID uint64) (bool, error)
+}
+
+type metafieldRepository struct {
+	db *gorm.DB
+}
+
+func NewMetafieldRepository(db *gorm.DB) MetafieldRepository {
+	return &metafieldRepository{db: db}
+}
+
+func (r *metafieldRepository) CreateDefinition(definition *model.MetafieldDefinition) error {
+	return r.db.Create(definition).Error
+}
+
+func (r *metafieldRepository) GetDefinition(id uint64) (*model.MetafieldDefinition, error) {
+	var definition model.MetafieldDefinition
+	if err := r.db.First(&definition, id).Error; err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func (r *metafieldRepository) FindDefinition(ownerType, namespace, key string) (*model.MetafieldDefinition, error) {
+	var definition model.MetafieldDefinition
+	err := r.db.Where("owner_type = ? AND namespace = ? AND key = ?", ownerType, namespace, key).First(&definition).Error
+	if err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func (r *metafieldRepository) GetDefinitions(ownerType string) ([]model.MetafieldDefinition, error) {
+	query := r.db.Order("owner_type ASC, namespace ASC, key ASC")
+	if ownerType != "" {
+		query = query.Where("owner_type = ?", ownerType)
+	}
+	var definitions []model.MetafieldDefinition
+	err := query.Find(&definitions).Error
+	return definitions, err
+}
+
+func (r *metafieldRepository) UpdateDefinition(definition *model.MetafieldDefinition) error {
+	return r.db.Model(definition).Select("name", "description", "visibility").Updates(definition).Error
+}
+
+func (r *metafieldRepository) DeleteDefinition(id uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("definition_id = ?", id).Delete(&model.Metafield{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.MetafieldDefinition{}, id).Error
+	})
+}
+
+func (r *metafieldRepository) GetByOwner(ownerType string, ownerID uint64, publicOnly bool) ([]model.Metafield, error) {
+	query := r.db.Joins("Definition").
+		Where("metafield.owner_type = ? AND metafield.owner_id = ?", ownerType, ownerID)
+	if publicOnly {
+		query = query.Where(`"Definition".visibility = ?`, model.MetafieldVisibilityPublic)
+	}
+
+	var metafields []model.Metafield
+	err := query.Order(`"Definition".namespace ASC, "Definition".key ASC`).Find(&metafields).Error
+	return metafields, err
+}
+
+func (r *metafieldRepository) Upsert(metafield *model.Metafield) error {
+	return r.db.Omit("Definition").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "owner_type"}, {Name: "owner_id"}, {Name: "definition_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(metafield).Error
+}
+
+func (r *metafieldRepository) Delete(ownerType string, ownerID, definitionID uint64) (bool, error) {
+	result := r.db.
+		Where("owner_type = ? AND owner_id = ? AND definition_id = ?", ownerType, ownerID, definitionID).
+		Delete(&model.Metafield{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *metafieldRepository) OwnerExists(ownerType string, ownerID uint64) (bool, error) {
+	var count int64
+	err := r.db.Table(ownerType).Where("id = ?", ownerID).Count(&count).Error
+	return count > 0, err
+}
+
+func deleteMetafields(tx *gorm.DB, ownerType string, ownerID uint64) error {
+	return tx.Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).Delete(&model.Metafield{}).Error
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OptionRepository interface {
+	CreateType(optionType *model.OptionType) error
+	GetType(id uint64) (*model.OptionType, error)
+	GetTypesByProduct(productID uint64) ([]model.OptionType, error)
+	UpdateType(optionType *model.OptionType) error
+	DeleteType(id uint64) error
+	CreateValue(value *model.OptionValue) error
+	GetValue(id uint64) (*model.OptionValue, error)
+	GetValuesByIDs(ids []uint64) ([]model.OptionValue, error)
+	DeleteValue(id uint64) error
+	ReorderTypes(productID uint64, ids []uint64) error
+	ReorderValues(optionTypeID uint64, ids []uint64) error
+	CountVariantsUsingType(optionTypeID uint64) (int64, error)
+	CountVariantsUsingValue(optionValueID uint64) (int64, error)
+}
+
+type optionRepository struct {
+	db *gorm.DB
+}
+
+func NewOptionRepository(db *gorm.DB) OptionRepository {
+	return &optionRepository{db: db}
+}
+
+func (r *optionRepository) CreateType(optionType *model.OptionType) error {
+	return r.db.Create(optionType).Error
+}
+
+func (r *optionRepository) GetType(id uint64) (*model.OptionType, error) {
+	var optionType model.OptionType
+	err := r.db.Preload("Values", byPosition).First(&optionType, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &optionType, nil
+}
+
+func (r *optionRepository) GetTypesByProduct(productID uint64) ([]model.OptionType, error) {
+	var optionTypes []model.OptionType
+	err := r.db.Preload("Values", byPosition).
+		Where("product_id = ?", productID).
+		Scopes(byPosition).
+		Find(&optionTypes).Error
+	return optionTypes, err
+}
+
+// UpdateType saves the name only
+func (r *optionRepository) UpdateType(optionType *model.OptionType) error {
+	return r.db.Model(optionType).Select("name").Updates(optionType).Error
+}
+
+func (r *optionRepository) DeleteType(id uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("option_type_id = ?", id).Delete(&model.OptionValue{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.OptionType{}, id).Error
+	})
+}
+
+func (r *optionRepository) CreateValue(value *model.OptionValue) error {
+	return r.db.Create(value).Error
+}
+
+func (r *optionRepository) GetValue(id uint64) (*model.OptionValue, error) {
+	var value model.OptionValue
+	err := r.db.First(&value, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func (r *optionRepository) GetValuesByIDs(ids []uint64) ([]model.OptionValue, error) {
+	var values []model.OptionValue
+	err := r.db.Where("id IN ?", ids).Find(&values).Error
+	return values, err
+}
+
+func (r *optionRepository) DeleteValue(id uint64) error {
+	return r.db.Delete(&model.OptionValue{}, id).Error
+}
+
+// ReorderTypes requires ids to name every option type of the product exactly once
+func (r *optionRepository) ReorderTypes(productID uint64, ids []uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var typeIDs []uint64
+		err := tx.Model(&model.OptionType{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("product_id = ?", productID).
+			Pluck("id", &typeIDs).Error
+		if err != nil {
+			return err
+		}
+		if !sameIDSet(typeIDs, ids) {
+			return ErrSiblingSetMismatch
+		}
+
+		for position, id := range ids {
+			if err := tx.Model(&model.OptionType{}).Where("id = ?", id).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ReorderValues requires ids to name every value of the option type exactly once
+func (r *optionRepository) ReorderValues(optionTypeID uint64, ids []uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var valueIDs []uint64
+		err := tx.Model(&model.OptionValue{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("option_type_id = ?", optionTypeID).
+			Pluck("id", &valueIDs).Error
+		if err != nil {
+			return err
+		}
+		if !sameIDSet(valueIDs, ids) {
+			return ErrSiblingSetMismatch
+		}
+
+		for position, id := range ids {
+			if err := tx.Model(&model.OptionValue{}).Where("id = ?", id).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *optionRepository) CountVariantsUsingType(optionTypeID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.VariantOptionValue{}).Where("option_type_id = ?", optionTypeID).Count(&count).Error
+	return count, err
+}
+
+func (r *optionRepository) CountVariantsUsingValue(optionValueID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.VariantOptionValue{}).Where("option_value_id = ?", optionValueID).Count(&count).Error
+	return count, err
+}
+
+func byPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC, id ASC")
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OTPRateLimit struct {
+	ResendInterval time.Duration
+	PerHour        int // 0 for no cap
+}
+
+type PhoneOTPRepository interface {
+	Issue(otp *model.PhoneOTP, limit OTPRateLimit) (time.Duration, error)
+	GetLatestActive(phone string) (*model.PhoneOTP, error)
+	IncrementAttempts(id uint64, max int) (int, error)
+	Consume(id uint64) error
+}
+
+type phoneOTPRepository struct {
+	db *gorm.DB
+}
+
+func NewPhoneOTPRepository(db *gorm.DB) PhoneOTPRepository {
+	return &phoneOTPRepository{db: db}
+}
+
+// Issue replaces the number's outstanding codes with otp, or returns the wait when limit forbids it
+func (r *phoneOTPRepository) Issue(otp *model.PhoneOTP, limit OTPRateLimit) (time.Duration, error) {
+	var wait time.Duration
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "phone_otp:"+otp.Phone).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		var latest model.PhoneOTP
+		err := tx.Where("phone = ?", otp.Phone).Order("created_at DESC").Limit(1).Find(&latest).Error
+		if err != nil {
+			return err
+		}
+		if latest.ID != 0 {
+			if wait = latest.CreatedAt.Add(limit.ResendInterval).Sub(now); wait > 0 {
+				return nil
+			}
+		}
+
+		if limit.PerHour > 0 {
+			var count int64
+			err := tx.Model(&model.PhoneOTP{}).
+				Where("phone = ? AND created_at >= ?", otp.Phone, now.Add(-time.Hour)).
+				Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count >= int64(limit.PerHour) {
+				wait = time.Hour
+				return nil
+			}
+		}
+
+		err = tx.Model(&model.PhoneOTP{}).
+			Where("phone = ? AND consumed_at IS NULL", otp.Phone).
+			Update("consumed_at", now).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(otp).Error
+	})
+	return wait, err
+}
+
+func (r *phoneOTPRepository) GetLatestActive(phone string) (*model.PhoneOTP, error) {
+	var otp model.PhoneOTP
+	err := r.db.Where("phone = ? AND consumed_at IS NULL AND expires_at > ?", phone, time.Now()).
+		Order("created_at DESC").
+		First(&otp).Error
+	if err != nil {
+		return nil, err
+	}
+	return &otp, nil
+}
+
+// IncrementAttempts returns the attempts made, or ErrTokenAlreadyUsed when none are left
+func (r *phoneOTPRepository) IncrementAttempts(id uint64, max int) (int, error) {
+	var otp model.PhoneOTP
+	result := r.db.Model(&otp).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "attempts"}}}).
+		Where("id = ? AND attempts < ? AND consumed_at IS NULL", id, max).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, ErrTokenAlreadyUsed
+	}
+	return otp.Attempts, nil
+}
+
+func (r *phoneOTPRepository) Consume(id uint64) error {
+	result := r.db.Model(&model.PhoneOTP{}).
+		Where("id = ? AND consumed_at IS NULL", id).
+		Update("consumed_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTokenAlreadyUsed
+	}
+	return nil
+}
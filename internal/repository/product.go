@@ -2,18 +2,36 @@ package repository
 
 import (
 	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// productQueryFields are the product fields clients may sort and filter by
+var productQueryFields = pagination.Fields{
+	"id":        {Column: "id", Type: pagination.FieldInteger},
+	"name":      {Column: "name", Type: pagination.FieldString},
+	"slug":      {Column: "slug", Type: pagination.FieldString},
+	"status":    {Column: "status", Type: pagination.FieldString},
+	"brandId":   {Column: "brand_id", Type: pagination.FieldInteger, Nullable: true},
+	"createdAt": {Column: "created_at", Type: pagination.FieldTime},
+	"updatedAt": {Column: "updated_at", Type: pagination.FieldTime},
+}
+
 type ProductRepository interface {
 	Create(product *model.Product) error
 	FindAll() ([]model.Product, error)
 	FindByID(id uint64) (*model.Product, error)
+	FindBySlug(slug string) (*model.Product, error)
 	Update(product *model.Product) error
 	Delete(id uint64) error
-	FindWithPagination(page, limit int) ([]model.Product, int64, error)
+	FindWithPagination(filter ProductFilter, params pagination.PaginationParams) ([]model.Product, pagination.PageInfo, error)
+	Facets(filter ProductFilter) (*ProductFacets, error)
+	FindByCategoryIDs(categoryIDs []uint64, page, limit int) ([]model.Product, int64, error)
+	UpdateWithCategories(product *model.Product, links []model.ProductCategory) error
 	Count() (int64, error)
+	Search(query string, page, limit int) ([]ProductSearchHit, int64, error)
 }
 
 type productRepository struct {
@@ -36,7 +54,16 @@ func (r *productRepository) FindAll() ([]model.Product, error) {
 
 func (r *productRepository) FindByID(id uint64) (*model.Product, error) {
 	var product model.Product
-	err := r.db.First(&product, id).Error
+	err := r.db.Scopes(withProductDetails).First(&product, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *productRepository) FindBySlug(slug string) (*model.Product, error) {
+	var product model.Product
+	err := r.db.Scopes(withProductDetails).Where("slug = ?", slug).First(&product).Error
 	if err != nil {
 		return nil, err
 	}
@@ -44,19 +71,92 @@ func (r *productRepository) FindByID(id uint64) (*model.Product, error) {
 }
 
 func (r *productRepository) Update(product *model.Product) error {
-	return r.db.Save(product).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return saveProduct(tx, product)
+	})
+}
+
+func (r *productRepository) UpdateWithCategories(product *model.Product, links []model.ProductCategory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := saveProduct(tx, product); err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", product.ID).Delete(&model.ProductCategory{}).Error; err != nil {
+			return err
+		}
+		for i := range links {
+			links[i].ProductID = product.ID
+		}
+		if len(links) > 0 {
+			if err := tx.Create(&links).Error; err != nil {
+				return err
+			}
+		}
+		product.Categories = links
+		return nil
+	})
 }
 
+func saveProduct(tx *gorm.DB, product *model.Product) error {
+	var current model.Product
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "slug").First(&current, product.ID).Error
+	if err != nil {
+		return err
+	}
+	if err := tx.Omit(clause.Associations).Save(product).Error; err != nil {
+		return err
+	}
+	return recordSlugChange(tx, model.SlugEntityProduct, product.ID, current.Slug, product.Slug)
+}
+
+// Delete removes the product with everything keyed by it
 func (r *productRepository) Delete(id uint64) error {
-	return r.db.Delete(&model.Product{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", id).Delete(&model.ProductCategory{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", id).Delete(&model.ProductAttributeValue{}).Error; err != nil {
+			return err
+		}
+		optionTypes := tx.Model(&model.OptionType{}).Select("id").Where("product_id = ?", id)
+		if err := tx.Where("option_type_id IN (?)", optionTypes).Delete(&model.VariantOptionValue{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("option_type_id IN (?)", optionTypes).Delete(&model.OptionValue{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", id).Delete(&model.OptionType{}).Error; err != nil {
+			return err
+		}
+		if err := deleteSlugRedirects(tx, model.SlugEntityProduct, id); err != nil {
+			return err
+		}
+		if err := deleteMetafields(tx, model.MetafieldOwnerProduct, id); err != nil {
+			return err
+		}
+		return tx.Delete(&model.Product{}, id).Error
+	})
+}
+
+func (r *productRepository) FindWithPagination(filter ProductFilter, params pagination.PaginationParams) ([]model.Product, pagination.PageInfo, error) {
+	var products []model.Product
+	filtered := func(db *gorm.DB) *gorm.DB {
+		return db.Model(&model.Product{}).Scopes(filter.scope(""))
+	}
+	query := pageQuery{table: "product", fields: productQueryFields, sort: filter.Query.Sort}
+	info, err := findPage(r.db, filtered, query, params, &products, withProductDetails)
+	return products, info, err
 }
 
-func (r *productRepository) FindWithPagination(page, limit int) ([]model.Product, int64, error) {
+func (r *productRepository) FindByCategoryIDs(categoryIDs []uint64, page, limit int) ([]model.Product, int64, error) {
 	var products []model.Product
 	var total int64
 
+	assigned := r.db.Model(&model.ProductCategory{}).Select("product_id").Where("category_id IN ?", categoryIDs)
+	query := r.db.Model(&model.Product{}).Where("id IN (?)", assigned)
+
 	// Get total count
-	if err := r.db.Model(&model.Product{}).Count(&total).Error; err != nil {
+	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -64,10 +164,26 @@ func (r *productRepository) FindWithPagination(page, limit int) ([]model.Product
 	offset := (page - 1) * limit
 
 	// Fetch paginated results
-	err := r.db.Offset(offset).Limit(limit).Find(&products).Error
+	err := query.Scopes(withProductDetails).
+		Order("id ASC").
+		Offset(offset).Limit(limit).
+		Find(&products).Error
 	return products, total, err
 }
 
+// withProductDetails preloads the brand, category assignments and attribute values
+func withProductDetails(db *gorm.DB) *gorm.DB {
+	return db.Preload("Brand").
+		Preload("Categories", withPrimaryFirst).
+		Preload("AttributeValues.Attribute").
+		Preload("AttributeValues.Option")
+}
+
+// withPrimaryFirst orders preloaded category assignments with the primary one first
+func withPrimaryFirst(db *gorm.DB) *gorm.DB {
+	return db.Order("is_primary DESC, category_id ASC")
+}
+
 func (r *productRepository) Count() (int64, error) {
 	var count int64
 	err := r.db.Model(&model.Product{}).Count(&count).Error
@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// maxFacetBuckets bounds the brand and category facets to their most common values
+const maxFacetBuckets = 50
+
+// Facet names, used to leave a facet's own filter out when counting it
+const (
+	facetBrand    = "brand"
+	facetCategory = "category"
+	facetStatus   = "status"
+	facetPrice    = "price"
+	facetStock    = "stock"
+)
+
+// ProductFilter fields must all match; values within one field are alternatives
+type ProductFilter struct {
+	BrandIDs    []uint64
+	CategoryIDs []uint64 // already expanded to subcategories
+	Status      string
+	MinPrice    *float64 // some active variant costs at least this much
+	MaxPrice    *float64 // and at most this much
+	InStock     bool     // some active variant, within the price range, has stock
+	Attributes  []AttributeFilter
+	Query       pagination.QuerySpec // sort and filter[...] parameters, see productQueryFields
+}
+
+// AttributeFilter sets only the fields for the attribute's type
+type AttributeFilter struct {
+	AttributeID uint64
+	OptionIDs   []uint64 // enum
+	Texts       []string // text, compared case-insensitively
+	Bool        *bool    // boolean
+	Min         *float64 // number
+	Max         *float64 // number
+}
+
+// Each facet is counted with every filter except its own
+type ProductFacets struct {
+	Price      *RangeFacet      `json:"price"` // nil when no matching product has an active variant
+	InStock    int64            `json:"inStock"`
+	Status     []FacetBucket    `json:"status"`
+	Brands     []FacetBucket    `json:"brands"`
+	Categories []FacetBucket    `json:"categories"`
+	Attributes []AttributeFacet `json:"attributes"`
+}
+
+type FacetBucket struct {
+	Value string `json:"value"` // the value to filter by
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+type RangeFacet struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+type AttributeFacet struct {
+	Code    string        `json:"code"`
+	Name    string        `json:"name"`
+	Type    string        `json:"type"`
+	Unit    string        `json:"unit,omitempty"`
+	Buckets []FacetBucket `json:"buckets,omitempty"`
+	Range   *RangeFacet   `json:"range,omitempty"`
+}
+
+// scope leaves out the filter of the named facet ("" applies everything)
+func (f ProductFilter) scope(except string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(f.BrandIDs) > 0 && except != facetBrand {
+			db = db.Where("product.brand_id IN ?", f.BrandIDs)
+		}
+		if len(f.CategoryIDs) > 0 && except != facetCategory {
+			db = db.Where("product.id IN (?)",
+				db.Session(&gorm.Session{NewDB: true}).Model(&model.ProductCategory{}).
+					Select("product_id").Where("category_id IN ?", f.CategoryIDs))
+		}
+		if f.Status != "" && except != facetStatus {
+			db = db.Where("product.status = ?", f.Status)
+		}
+
+		variant := f
+		if except == facetPrice {
+			variant.MinPrice, variant.MaxPrice = nil, nil
+		}
+		if except == facetStock {
+			variant.InStock = false
+		}
+		db = variant.variantScope(db)
+
+		for _, attribute := range f.Attributes {
+			if except == attributeFacet(attribute.AttributeID) {
+				continue
+			}
+			db = attribute.scope(db)
+		}
+		return db.Scopes(withFilters("product", productQueryFields, f.Query.Filters))
+	}
+}
+
+func (f ProductFilter) variantScope(db *gorm.DB) *gorm.DB {
+	if f.MinPrice == nil && f.MaxPrice == nil && !f.InStock {
+		return db
+	}
+	variants := db.Session(&gorm.Session{NewDB: true}).Model(&model.Variant{}).
+		Select("1").Where("variant.product_id = product.id AND variant.is_active")
+	if f.MinPrice != nil {
+		variants = variants.Where("variant.price >= ?", *f.MinPrice)
+	}
+	if f.MaxPrice != nil {
+		variants = variants.Where("variant.price <= ?", *f.MaxPrice)
+	}
+	if f.InStock {
+		variants = variants.Where("variant.stock_quantity > 0")
+	}
+	return db.Where("EXISTS (?)", variants)
+}
+
+func (a AttributeFilter) scope(db *gorm.DB) *gorm.DB {
+	values := db.Session(&gorm.Session{NewDB: true}).Model(&model.ProductAttributeValue{}).
+		Select("1").
+		Where("product_attribute_value.product_id = product.id AND product_attribute_value.attribute_id = ?", a.AttributeID)
+	switch {
+	case len(a.OptionIDs) > 0:
+		values = values.Where("product_attribute_value.option_id IN ?", a.OptionIDs)
+	case len(a.Texts) > 0:
+		values = values.Where("lower(product_attribute_value.text_value) IN ?", a.Texts)
+	case a.Bool != nil:
+		values = values.Where("product_attribute_value.bool_value = ?", *a.Bool)
+	default:
+		if a.Min != nil {
+			values = values.Where("product_attribute_value.number_value >= ?", *a.Min)
+		}
+		if a.Max != nil {
+			values = values.Where("product_attribute_value.number_value <= ?", *a.Max)
+		}
+	}
+	return db.Where("EXISTS (?)", values)
+}
+
+func attributeFacet(attributeID uint64) string {
+	return "attribute:" + strconv.FormatUint(attributeID, 10)
+}
+
+func (r *productRepository) Facets(filter ProductFilter) (*ProductFacets, error) {
+	facets := &ProductFacets{}
+	var err error
+
+	if facets.Price, err = r.priceFacet(filter); err != nil {
+		return nil, err
+	}
+
+	inStock := filter
+	inStock.InStock = true
+	if err := r.db.Model(&model.Product{}).Scopes(inStock.scope("")).Count(&facets.InStock).Error; err != nil {
+		return nil, err
+	}
+
+	err = r.db.Model(&model.Product{}).Scopes(filter.scope(facetStatus)).
+		Select("product.status AS value, product.status AS label, COUNT(*) AS count").
+		Group("product.status").Order("count DESC, value ASC").
+		Scan(&facets.Status).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Model(&model.Product{}).Scopes(filter.scope(facetBrand)).
+		Joins("JOIN brand ON brand.id = product.brand_id").
+		Select("brand.id AS value, brand.name AS label, COUNT(*) AS count").
+		Group("brand.id, brand.name").Order("count DESC, label ASC").Limit(maxFacetBuckets).
+		Scan(&facets.Brands).Error
+	if err != nil {
+		return nil, err
+	}
+
+	products := r.db.Model(&model.Product{}).Scopes(filter.scope(facetCategory)).Select("product.id")
+	err = r.db.Model(&model.ProductCategory{}).
+		Joins("JOIN category ON category.id = product_category.category_id").
+		Where("product_category.product_id IN (?)", products).
+		Select("category.id AS value, category.name AS label, COUNT(*) AS count").
+		Group("category.id, category.name").Order("count DESC, label ASC").Limit(maxFacetBuckets).
+		Scan(&facets.Categories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if facets.Attributes, err = r.attributeFacets(filter); err != nil {
+		return nil, err
+	}
+	return facets, nil
+}
+
+// With the stock filter on, only variants in stock count toward the price range
+func (r *productRepository) priceFacet(filter ProductFilter) (*RangeFacet, error) {
+	products := r.db.Model(&model.Product{}).Scopes(filter.scope(facetPrice)).Select("product.id")
+	query := r.db.Model(&model.Variant{}).
+		Where("variant.is_active AND variant.product_id IN (?)", products)
+	if filter.InStock {
+		query = query.Where("variant.stock_quantity > 0")
+	}
+
+	var result struct {
+		Min *float64
+		Max *float64
+	}
+	if err := query.Select("MIN(variant.price) AS min, MAX(variant.price) AS max").Scan(&result).Error; err != nil {
+		return nil, err
+	}
+	if result.Min == nil || result.Max == nil {
+		return nil, nil
+	}
+	return &RangeFacet{Min: *result.Min, Max: *result.Max}, nil
+}
+
+type attributeValueCount struct {
+	AttributeID uint64
+	OptionID    *uint64
+	BoolValue   *bool
+	Count       int64
+	Min         *float64
+	Max         *float64
+}
+
+// Unfiltered attributes share one pass; a filtered attribute gets its own without its filter
+func (r *productRepository) attributeFacets(filter ProductFilter) ([]AttributeFacet, error) {
+	filtered := make([]uint64, 0, len(filter.Attributes))
+	for _, attribute := range filter.Attributes {
+		filtered = append(filtered, attribute.AttributeID)
+	}
+
+	rows, err := r.attributeValueCounts(filter, "", nil, filtered)
+	if err != nil {
+		return nil, err
+	}
+	for _, attributeID := range filtered {
+		own, err := r.attributeValueCounts(filter, attributeFacet(attributeID), []uint64{attributeID}, nil)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, own...)
+	}
+	if len(rows) == 0 {
+		return []AttributeFacet{}, nil
+	}
+
+	byAttribute := make(map[uint64][]attributeValueCount)
+	ids := make([]uint64, 0, len(rows))
+	for _, row := range rows {
+		if _, ok := byAttribute[row.AttributeID]; !ok {
+			ids = append(ids, row.AttributeID)
+		}
+		byAttribute[row.AttributeID] = append(byAttribute[row.AttributeID], row)
+	}
+	var attributes []model.Attribute
+	err = r.db.Preload("Options").
+		Where("id IN ? AND type IN ?", ids, []string{model.AttributeTypeEnum, model.AttributeTypeBoolean, model.AttributeTypeNumber}).
+		Order("name ASC, id ASC").
+		Find(&attributes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	facets := make([]AttributeFacet, 0, len(attributes))
+	for _, attribute := range attributes {
+		facet := AttributeFacet{Code: attribute.Code, Name: attribute.Name, Type: attribute.Type, Unit: attribute.Unit}
+		options := make(map[uint64]model.AttributeOption, len(attribute.Options))
+		for _, option := range attribute.Options {
+			options[option.ID] = option
+		}
+
+		for _, row := range byAttribute[attribute.ID] {
+			switch attribute.Type {
+			case model.AttributeTypeEnum:
+				if row.OptionID == nil {
+					continue
+				}
+				option := options[*row.OptionID]
+				facet.Buckets = append(facet.Buckets, FacetBucket{Value: option.Value, Label: option.Value, Count: row.Count})
+			case model.AttributeTypeBoolean:
+				if row.BoolValue == nil {
+					continue
+				}
+				value := strconv.FormatBool(*row.BoolValue)
+				facet.Buckets = append(facet.Buckets, FacetBucket{Value: value, Label: value, Count: row.Count})
+			case model.AttributeTypeNumber:
+				if row.Min != nil && row.Max != nil {
+					facet.Range = &RangeFacet{Min: *row.Min, Max: *row.Max}
+				}
+			}
+		}
+		if len(facet.Buckets) == 0 && facet.Range == nil {
+			continue
+		}
+		sort.SliceStable(facet.Buckets, func(i, j int) bool { return facet.Buckets[i].Count > facet.Buckets[j].Count })
+		facets = append(facets, facet)
+	}
+	return facets, nil
+}
+
+// only limits the attributes counted, skip leaves some out
+func (r *productRepository) attributeValueCounts(filter ProductFilter, except string, only, skip []uint64) ([]attributeValueCount, error) {
+	products := r.db.Model(&model.Product{}).Scopes(filter.scope(except)).Select("product.id")
+	query := r.db.Model(&model.ProductAttributeValue{}).
+		Where("product_attribute_value.product_id IN (?)", products)
+	if len(only) > 0 {
+		query = query.Where("product_attribute_value.attribute_id IN ?", only)
+	}
+	if len(skip) > 0 {
+		query = query.Where("product_attribute_value.attribute_id NOT IN ?", skip)
+	}
+
+	// Number values collapse into one row per attribute carrying the range
+	var rows []attributeValueCount
+	err := query.Select(`product_attribute_value.attribute_id,
+			product_attribute_value.option_id,
+			product_attribute_value.bool_value,
+			COUNT(*) AS count,
+			MIN(product_attribute_value.number_value) AS min,
+			MAX(product_attribute_value.number_value) AS max`).
+		Group("product_attribute_value.attribute_id, product_attribute_value.option_id, product_attribute_value.bool_value").
+		Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// searchQuery must use the configuration search_vector is built with
+const searchQuery = "websearch_to_tsquery('english', ?)"
+
+// ts_headline markers become <mark> tags after HTML escaping. They are stripped
+// from the product text first, so every marker in the output is one ts_headline added.
+const (
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+	unmarked       = "translate(%s, chr(2) || chr(3), '')"
+)
+
+const (
+	nameHeadlineOptions    = `StartSel="` + highlightStart + `", StopSel="` + highlightStop + `", HighlightAll=true`
+	snippetHeadlineOptions = `StartSel="` + highlightStart + `", StopSel="` + highlightStop + `", MaxFragments=2, MinWords=10, MaxWords=30, FragmentDelimiter=" … "`
+)
+
+// ProductSearchHit highlights are HTML with matches wrapped in <mark>
+type ProductSearchHit struct {
+	Product       model.Product
+	Rank          float64
+	NameHighlight string
+	Snippet       string
+}
+
+// Search ranks active products against a web-style query (quoted phrases, OR, -word)
+func (r *productRepository) Search(query string, page, limit int) ([]ProductSearchHit, int64, error) {
+	matches := func(db *gorm.DB) *gorm.DB {
+		return db.Model(&model.Product{}).
+			Where("status = ? AND search_vector @@ "+searchQuery, "active", query)
+	}
+
+	var total int64
+	if err := r.db.Scopes(matches).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []ProductSearchHit{}, 0, nil
+	}
+
+	// Build headlines for the returned page only; normalization 1 divides by document length
+	ranked := r.db.Scopes(matches).
+		Select("id, ts_rank_cd(search_vector, "+searchQuery+", 1) AS rank", query).
+		Order("rank DESC, id ASC").
+		Offset((page - 1) * limit).Limit(limit)
+
+	var rows []struct {
+		ID            uint64
+		Rank          float64
+		NameHighlight string
+		Snippet       string
+	}
+	err := r.db.Table("(?) AS hit", ranked).
+		Joins("JOIN product ON product.id = hit.id").
+		Select(`hit.id, hit.rank,
+			ts_headline('english', `+fmt.Sprintf(unmarked, "product.name")+`, `+searchQuery+`, ?) AS name_highlight,
+			ts_headline('english', `+fmt.Sprintf(unmarked, "concat_ws(' ', nullif(product.short_description, ''), nullif(product.description, ''))")+`, `+searchQuery+`, ?) AS snippet`,
+			query, nameHeadlineOptions, query, snippetHeadlineOptions).
+		Order("hit.rank DESC, hit.id ASC").
+		Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return []ProductSearchHit{}, total, err
+	}
+
+	ids := make([]uint64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	var products []model.Product
+	if err := r.db.Scopes(withProductDetails).Where("id IN ?", ids).Find(&products).Error; err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[uint64]model.Product, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+
+	hits := make([]ProductSearchHit, 0, len(rows))
+	for _, row := range rows {
+		product, ok := byID[row.ID]
+		if !ok {
+			continue // deleted between the two queries
+		}
+		hits = append(hits, ProductSearchHit{
+			Product:       product,
+			Rank:          row.Rank,
+			NameHighlight: markHighlights(row.NameHighlight),
+			Snippet:       markHighlights(row.Snippet),
+		})
+	}
+	return hits, total, nil
+}
+
+func markHighlights(text string) string {
+	text = html.EscapeString(text)
+	text = strings.ReplaceAll(text, highlightStart, "<mark>")
+	return strings.ReplaceAll(text, highlightStop, "</mark>")
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// likeEscaper escapes the LIKE wildcards in a value matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// withFilters takes columns from the whitelist only and always binds values
+func withFilters(table string, fields pagination.Fields, filters []pagination.Filter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		conditions, err := fields.Conditions(filters)
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+		for _, condition := range conditions {
+			column := clause.Column{Table: table, Name: condition.Column}
+			switch condition.Operator {
+			case pagination.OpIn:
+				db = db.Where(clause.IN{Column: column, Values: condition.Values})
+			case pagination.OpGte:
+				db = db.Where(clause.Gte{Column: column, Value: condition.Values[0]})
+			case pagination.OpLte:
+				db = db.Where(clause.Lte{Column: column, Value: condition.Values[0]})
+			case pagination.OpLike:
+				pattern := "%" + likeEscaper.Replace(condition.Values[0].(string)) + "%"
+				db = db.Where(clause.Expr{SQL: "? ILIKE ?", Vars: []any{column, pattern}})
+			default:
+				db = db.Where(clause.Eq{Column: column, Value: condition.Values[0]})
+			}
+		}
+		return db
+	}
+}
+
+type pageQuery struct {
+	table    string
+	fields   pagination.Fields
+	sort     []pagination.Sort
+	defaults []pagination.Sort
+}
+
+// findPage pages by number or by cursor as params ask
+func findPage[T any](db *gorm.DB, filtered func(*gorm.DB) *gorm.DB, query pageQuery, params pagination.PaginationParams, dest *[]T, preload ...func(*gorm.DB) *gorm.DB) (pagination.PageInfo, error) {
+	var info pagination.PageInfo
+	orders, err := query.orders()
+	if err != nil {
+		return info, err
+	}
+
+	if params.WithTotal {
+		var total int64
+		if err := db.Scopes(filtered).Count(&total).Error; err != nil {
+			return info, err
+		}
+		info.Total = &total
+	}
+
+	rows := db.Scopes(filtered).Scopes(preload...)
+	if !params.UseCursor {
+		offset := (params.Page - 1) * params.Limit
+		err := rows.Order(query.orderBy(orders, false)).Offset(offset).Limit(params.Limit).Find(dest).Error
+		return info, err
+	}
+
+	// Seek past the cursor's row and fetch one extra to learn whether there is another page
+	for _, order := range orders {
+		if order.Nullable {
+			return info, fmt.Errorf("%w: cursor pages cannot sort by %s", pagination.ErrInvalidQuery, order.Column)
+		}
+	}
+	sortKey := pagination.SortKey(orders)
+	backward := false
+	var from []string
+	if params.Cursor != "" {
+		cursor, err := pagination.DecodeCursor(params.Cursor)
+		if err != nil {
+			return info, err
+		}
+		if cursor.Sort != sortKey || len(cursor.Keys) != len(orders) {
+			return info, fmt.Errorf("%w: cursor belongs to a different sort", pagination.ErrInvalidQuery)
+		}
+		keys := make([]any, len(orders))
+		for i, order := range orders {
+			if keys[i], err = order.ParseKey(cursor.Keys[i]); err != nil {
+				return info, fmt.Errorf("%w: invalid cursor", pagination.ErrInvalidQuery)
+			}
+		}
+		backward = cursor.Backward
+		from = cursor.Keys
+		rows = rows.Where(query.after(orders, keys, backward))
+	}
+
+	result := rows.Order(query.orderBy(orders, backward)).Limit(params.Limit + 1).Find(dest)
+	if result.Error != nil {
+		return info, result.Error
+	}
+	more := len(*dest) > params.Limit
+	if more {
+		*dest = (*dest)[:params.Limit]
+	}
+	if backward {
+		slices.Reverse(*dest)
+	}
+	if len(*dest) == 0 {
+		// Nothing past the cursor; offer the way back from its position
+		if from != nil {
+			back := pagination.Cursor{Sort: sortKey, Keys: from, Backward: !backward}.Encode()
+			if backward {
+				info.NextCursor = back
+			} else {
+				info.PrevCursor = back
+			}
+		}
+		return info, nil
+	}
+
+	hasNext, hasPrev := more, params.Cursor != ""
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		keys, err := cursorKeys(result.Statement, orders, &(*dest)[len(*dest)-1])
+		if err != nil {
+			return info, err
+		}
+		info.NextCursor = pagination.Cursor{Sort: sortKey, Keys: keys}.Encode()
+	}
+	if hasPrev {
+		keys, err := cursorKeys(result.Statement, orders, &(*dest)[0])
+		if err != nil {
+			return info, err
+		}
+		info.PrevCursor = pagination.Cursor{Sort: sortKey, Keys: keys, Backward: true}.Encode()
+	}
+	return info, nil
+}
+
+// orders ends the sort with the id so rows never tie
+func (q pageQuery) orders() ([]pagination.Order, error) {
+	sort := q.sort
+	if len(sort) == 0 {
+		sort = q.defaults
+	}
+	orders, err := q.fields.Orders(sort)
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range orders {
+		if order.Column == "id" {
+			return orders, nil
+		}
+	}
+	return append(orders, pagination.Order{Field: q.fields["id"]}), nil
+}
+
+func (q pageQuery) orderBy(orders []pagination.Order, reverse bool) clause.OrderBy {
+	columns := make([]clause.OrderByColumn, 0, len(orders))
+	for _, order := range orders {
+		columns = append(columns, clause.OrderByColumn{
+			Column: clause.Column{Table: q.table, Name: order.Column},
+			Desc:   order.Desc != reverse,
+		})
+	}
+	return clause.OrderBy{Columns: columns}
+}
+
+// after builds (a > ?) OR (a = ? AND b > ?) OR ..., reversed when paging backward
+func (q pageQuery) after(orders []pagination.Order, keys []any, backward bool) clause.Expression {
+	alternatives := make([]clause.Expression, 0, len(orders))
+	for i, order := range orders {
+		conditions := make([]clause.Expression, 0, i+1)
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, clause.Eq{Column: clause.Column{Table: q.table, Name: orders[j].Column}, Value: keys[j]})
+		}
+		column := clause.Column{Table: q.table, Name: order.Column}
+		if order.Desc != backward {
+			conditions = append(conditions, clause.Lt{Column: column, Value: keys[i]})
+		} else {
+			conditions = append(conditions, clause.Gt{Column: column, Value: keys[i]})
+		}
+		alternatives = append(alternatives, clause.And(conditions...))
+	}
+	return clause.Or(alternatives...)
+}
+
+func cursorKeys(stmt *gorm.Statement, orders []pagination.Order, row any) ([]string, error) {
+	value := reflect.ValueOf(row)
+	keys := make([]string, 0, len(orders))
+	for _, order := range orders {
+		field := stmt.Schema.LookUpField(order.Column)
+		if field == nil {
+			return nil, fmt.Errorf("no field for sort column %s", order.Column)
+		}
+		key, _ := field.ValueOf(stmt.Context, value)
+		keys = append(keys, pagination.FormatKey(key))
+	}
+	return keys, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// dryRunDB builds SQL without a database connection
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		NamingStrategy:       schema.NamingStrategy{SingularTable: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestWithFiltersEscapesLike(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"silk", "%silk%"},
+		{"50%", `%50\%%`},
+		{"a_b", `%a\_b%`},
+		{`back\slash`, `%back\\slash%`},
+	}
+	for _, tt := range tests {
+		filters := []pagination.Filter{{Field: "name", Operator: pagination.OpLike, Value: tt.value}}
+		stmt := dryRunDB(t).Scopes(withFilters("product", productQueryFields, filters)).
+			Find(&[]model.Product{}).Statement
+
+		if want := `SELECT * FROM "product" WHERE "product"."name" ILIKE $1`; stmt.SQL.String() != want {
+			t.Errorf("%q: SQL = %s, want %s", tt.value, stmt.SQL.String(), want)
+		}
+		if len(stmt.Vars) != 1 || stmt.Vars[0] != tt.want {
+			t.Errorf("%q: vars = %v, want [%s]", tt.value, stmt.Vars, tt.want)
+		}
+	}
+}
+
+func TestWithFiltersRejectsUnknownFields(t *testing.T) {
+	filters := []pagination.Filter{{Field: "name; DROP TABLE product", Operator: pagination.OpEq, Value: "x"}}
+	err := dryRunDB(t).Scopes(withFilters("product", productQueryFields, filters)).
+		Find(&[]model.Product{}).Error
+	if !errors.Is(err, pagination.ErrInvalidQuery) {
+		t.Errorf("err = %v, want ErrInvalidQuery", err)
+	}
+}
+
+func TestFindPageRejectsForeignCursors(t *testing.T) {
+	byCreated := []pagination.Sort{{Field: "createdAt", Desc: true}}
+	tests := []struct {
+		name   string
+		sort   []pagination.Sort
+		cursor string
+	}{
+		{"different sort", []pagination.Sort{{Field: "name"}}, pagination.Cursor{Sort: "-created_at,id", Keys: []string{"2025-08-17T05:39:06Z", "42"}}.Encode()},
+		{"different direction", []pagination.Sort{{Field: "createdAt"}}, pagination.Cursor{Sort: "-created_at,id", Keys: []string{"2025-08-17T05:39:06Z", "42"}}.Encode()},
+		{"missing key", byCreated, pagination.Cursor{Sort: "-created_at,id", Keys: []string{"2025-08-17T05:39:06Z"}}.Encode()},
+		{"tampered key", byCreated, pagination.Cursor{Sort: "-created_at,id", Keys: []string{"2025-08-17T05:39:06Z", "42 OR 1=1"}}.Encode()},
+		{"tampered encoding", byCreated, "eyJzIjoiLWNyZWF0ZWRfYXQsaWQi"},
+		{"nullable sort", []pagination.Sort{{Field: "brandId"}}, ""},
+	}
+	for _, tt := range tests {
+		query := pageQuery{table: "product", fields: productQueryFields, sort: tt.sort}
+		params := pagination.PaginationParams{Limit: 20, UseCursor: true, Cursor: tt.cursor}
+		products := []model.Product{}
+		filtered := func(db *gorm.DB) *gorm.DB { return db.Model(&model.Product{}) }
+		if _, err := findPage(dryRunDB(t), filtered, query, params, &products); !errors.Is(err, pagination.ErrInvalidQuery) {
+			t.Errorf("%s: err = %v, want ErrInvalidQuery", tt.name, err)
+		}
+	}
+}
+
+func TestFindPageEmptyPageLinksBack(t *testing.T) {
+	query := pageQuery{table: "product", fields: productQueryFields, sort: []pagination.Sort{{Field: "createdAt", Desc: true}}}
+	keys := []string{"2025-08-17T05:39:06Z", "42"}
+	filtered := func(db *gorm.DB) *gorm.DB { return db.Model(&model.Product{}) }
+
+	for _, backward := range []bool{false, true} {
+		cursor := pagination.Cursor{Sort: "-created_at,id", Keys: keys, Backward: backward}
+		params := pagination.PaginationParams{Limit: 20, UseCursor: true, Cursor: cursor.Encode()}
+		products := []model.Product{}
+		// A dry run returns no rows, like a cursor past the last product
+		info, err := findPage(dryRunDB(t), filtered, query, params, &products)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		back := pagination.Cursor{Sort: "-created_at,id", Keys: keys, Backward: !backward}.Encode()
+		next, prev := "", back
+		if backward {
+			next, prev = back, ""
+		}
+		if info.NextCursor != next || info.PrevCursor != prev {
+			t.Errorf("backward=%v: got next %q prev %q, want next %q prev %q", backward, info.NextCursor, info.PrevCursor, next, prev)
+		}
+	}
+}
+
+func TestPageQueryAfter(t *testing.T) {
+	query := pageQuery{table: "product", fields: productQueryFields, sort: []pagination.Sort{{Field: "createdAt", Desc: true}}}
+	orders, err := query.orders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []any{"2025-08-17T05:39:06Z", int64(42)}
+
+	tests := []struct {
+		backward bool
+		want     string
+	}{
+		{false, `SELECT * FROM "product" WHERE ("product"."created_at" < $1 OR ("product"."created_at" = $2 AND "product"."id" > $3))`},
+		{true, `SELECT * FROM "product" WHERE ("product"."created_at" > $1 OR ("product"."created_at" = $2 AND "product"."id" < $3))`},
+	}
+	for _, tt := range tests {
+		stmt := dryRunDB(t).Where(query.after(orders, keys, tt.backward)).Find(&[]model.Product{}).Statement
+		if stmt.SQL.String() != tt.want {
+			t.Errorf("backward=%v: SQL = %s, want %s", tt.backward, stmt.SQL.String(), tt.want)
+		}
+	}
+}
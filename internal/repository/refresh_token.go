@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// ErrTokenAlreadyRotated is returned when the token was already exchanged
+var ErrTokenAlreadyRotated = errors.New("refresh token already rotated")
+
+type RefreshTokenRepository interface {
+	Create(token *model.RefreshToken) error
+	GetByHash(tokenHash string) (*model.RefreshToken, error)
+	Rotate(current *model.RefreshToken, next *model.RefreshToken) error
+	RevokeFamily(familyID string) error
+	RevokeAllForUser(userID uint64) error
+	IsFamilyRevoked(familyID string) (bool, error)
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+func (r *refreshTokenRepository) Create(token *model.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *refreshTokenRepository) GetByHash(tokenHash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Rotate marks the current token as used and stores its successor
+func (r *refreshTokenRepository) Rotate(current *model.RefreshToken, next *model.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.RefreshToken{}).
+			Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", current.ID).
+			Update("rotated_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTokenAlreadyRotated
+		}
+		return tx.Create(next).Error
+	})
+}
+
+func (r *refreshTokenRepository) RevokeFamily(familyID string) error {
+	return r.db.Model(&model.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *refreshTokenRepository) RevokeAllForUser(userID uint64) error {
+	return r.db.Model(&model.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// IsFamilyRevoked reports whether no live token is left in the family
+func (r *refreshTokenRepository) IsFamilyRevoked(familyID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Count(&count).Error
+	return count == 0, err
+}
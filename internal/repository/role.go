@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type RoleRepository interface {
+	Create(role *model.Role) error
+	GetByID(id uint64) (*model.Role, error)
+	GetAll() ([]model.Role, error)
+	Update(role *model.Role) error
+	ReplacePermissions(role *model.Role, permissions []model.Permission) error
+	Delete(id uint64) error
+	GetAllPermissions() ([]model.Permission, error)
+	GetPermissionsByNames(names []string) ([]model.Permission, error)
+	AssignToUser(userID, roleID uint64) error
+	RemoveFromUser(userID, roleID uint64) error
+	GetByUserID(userID uint64) ([]model.Role, error)
+	GetPermissionNamesByUserID(userID uint64) ([]string, error)
+}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) Create(role *model.Role) error {
+	return r.db.Create(role).Error
+}
+
+func (r *roleRepository) GetByID(id uint64) (*model.Role, error) {
+	var role model.Role
+	err := r.db.Preload("Permissions").First(&role, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) GetAll() ([]model.Role, error) {
+	var roles []model.Role
+	err := r.db.Preload("Permissions").Order("name ASC").Find(&roles).Error
+	return roles, err
+}
+
+func (r *roleRepository) Update(role *model.Role) error {
+	return r.db.Omit("Permissions").Save(role).Error
+}
+
+func (r *roleRepository) ReplacePermissions(role *model.Role, permissions []model.Permission) error {
+	return r.db.Model(role).Association("Permissions").Replace(permissions)
+}
+
+func (r *roleRepository) Delete(id uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		role := &model.Role{ID: id}
+		if err := tx.Model(role).Association("Permissions").Clear(); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM user_role WHERE role_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Role{}, id).Error
+	})
+}
+
+func (r *roleRepository) GetAllPermissions() ([]model.Permission, error) {
+	var permissions []model.Permission
+	err := r.db.Order("name ASC").Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *roleRepository) GetPermissionsByNames(names []string) ([]model.Permission, error) {
+	var permissions []model.Permission
+	if len(names) == 0 {
+		return permissions, nil
+	}
+	err := r.db.Where("name IN ?", names).Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *roleRepository) AssignToUser(userID, roleID uint64) error {
+	return r.db.Model(&model.User{ID: userID}).Association("Roles").Append(&model.Role{ID: roleID})
+}
+
+func (r *roleRepository) RemoveFromUser(userID, roleID uint64) error {
+	return r.db.Model(&model.User{ID: userID}).Association("Roles").Delete(&model.Role{ID: roleID})
+}
+
+func (r *roleRepository) GetByUserID(userID uint64) ([]model.Role, error) {
+	var roles []model.Role
+	err := r.db.Preload("Permissions").
+		Joins("JOIN user_role ON user_role.role_id = role.id").
+		Where("user_role.user_id = ?", userID).
+		Order("role.name ASC").
+		Find(&roles).Error
+	return roles, err
+}
+
+func (r *roleRepository) GetPermissionNamesByUserID(userID uint64) ([]string, error) {
+	var names []string
+	err := r.db.Model(&model.Permission{}).
+		Distinct("permission.name").
+		Joins("JOIN role_permission ON role_permission.permission_id = permission.id").
+		Joins("JOIN user_role ON user_role.role_id = role_permission.role_id").
+		Where("user_role.user_id = ?", userID).
+		Pluck("permission.name", &names).Error
+	return names, err
+}
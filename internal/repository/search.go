@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+type SearchSuggestion struct {
+	Type  string  `json:"type"` // product, category or brand
+	ID    uint64  `json:"id"`
+	Name  string  `json:"name"`
+	Slug  string  `json:"slug"`
+	Score float64 `json:"score"`
+}
+
+// suggestCandidates trigram matches of each type are scored per suggestion returned
+const suggestCandidates = 4
+
+// suggestSQL matches by substring or trigram word similarity (<%) and scores
+// similarity + 0.3 for a prefix match + min(0.02 * ln(1 + n), 0.1), where n counts
+// a product's active variants in stock or a category's or brand's active products.
+const suggestSQL = `
+WITH product_match AS (
+	SELECT p.id, p.name, p.slug,
+		word_similarity(@q, lower(p.name)) AS similarity,
+		lower(p.name) LIKE @prefix AS prefix
+	FROM product p
+	WHERE p.status = 'active' AND (lower(p.name) LIKE @contains OR @q <% lower(p.name))
+	ORDER BY prefix DESC, similarity DESC
+	LIMIT @candidates
+), category_match AS (
+	SELECT c.id, c.name, c.slug,
+		word_similarity(@q, lower(c.name)) AS similarity,
+		lower(c.name) LIKE @prefix AS prefix
+	FROM category c
+	WHERE lower(c.name) LIKE @contains OR @q <% lower(c.name)
+	ORDER BY prefix DESC, similarity DESC
+	LIMIT @candidates
+), brand_match AS (
+	SELECT b.id, b.name, b.slug,
+		word_similarity(@q, lower(b.name)) AS similarity,
+		lower(b.name) LIKE @prefix AS prefix
+	FROM brand b
+	WHERE lower(b.name) LIKE @contains OR @q <% lower(b.name)
+	ORDER BY prefix DESC, similarity DESC
+	LIMIT @candidates
+)
+SELECT * FROM (
+	(SELECT 'product' AS type, m.id, m.name, m.slug,
+		m.similarity + CASE WHEN m.prefix THEN 0.3 ELSE 0.0 END + least(0.02 * ln(1 + (
+			SELECT count(*) FROM variant v WHERE v.product_id = m.id AND v.is_active AND v.stock_quantity > 0
+		)), 0.1) AS score
+	FROM product_match m ORDER BY score DESC, m.name LIMIT @limit)
+	UNION ALL
+	(SELECT 'category' AS type, m.id, m.name, m.slug,
+		m.similarity + CASE WHEN m.prefix THEN 0.3 ELSE 0.0 END + least(0.02 * ln(1 + (
+			SELECT count(*) FROM product_category pc JOIN product p ON p.id = pc.product_id
+			WHERE pc.category_id = m.id AND p.status = 'active'
+		)), 0.1) AS score
+	FROM category_match m ORDER BY score DESC, m.name LIMIT @limit)
+	UNION ALL
+	(SELECT 'brand' AS type, m.id, m.name, m.slug,
+		m.similarity + CASE WHEN m.prefix THEN 0.3 ELSE 0.0 END + least(0.02 * ln(1 + (
+			SELECT count(*) FROM product p WHERE p.brand_id = m.id AND p.status = 'active'
+		)), 0.1) AS score
+	FROM brand_match m ORDER BY score DESC, m.name LIMIT @limit)
+) suggestions
+ORDER BY score DESC, name`
+
+type SearchRepository interface {
+	Suggest(query string, limit int) ([]SearchSuggestion, error)
+}
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+func (r *searchRepository) Suggest(query string, limit int) ([]SearchSuggestion, error) {
+	escaped := likeEscaper.Replace(query)
+	prefix, contains := escaped+"%", "%"+escaped+"%"
+	if utf8.RuneCountInString(query) < 3 {
+		// Too short to hold a trigram inside a name; only its start can use the index
+		contains = prefix
+	}
+
+	suggestions := []SearchSuggestion{}
+	err := r.db.Raw(suggestSQL, map[string]any{
+		"q":          query,
+		"prefix":     prefix,
+		"contains":   contains,
+		"candidates": limit * suggestCandidates,
+		"limit":      limit,
+	}).Scan(&suggestions).Error
+	return suggestions, err
+}
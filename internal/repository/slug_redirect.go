@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SlugRedirectRepository interface {
+	Find(entityType, slug string) (*model.SlugRedirect, error)
+	IsTaken(entityType, slug string, entityID uint64) (bool, error)
+}
+
+type slugRedirectRepository struct {
+	db *gorm.DB
+}
+
+func NewSlugRedirectRepository(db *gorm.DB) SlugRedirectRepository {
+	return &slugRedirectRepository{db: db}
+}
+
+func (r *slugRedirectRepository) Find(entityType, slug string) (*model.SlugRedirect, error) {
+	var redirect model.SlugRedirect
+	err := r.db.Where("entity_type = ? AND slug = ?", entityType, slug).First(&redirect).Error
+	if err != nil {
+		return nil, err
+	}
+	return &redirect, nil
+}
+
+// IsTaken reports whether another entity of the type uses the slug now or used it before
+func (r *slugRedirectRepository) IsTaken(entityType, slug string, entityID uint64) (bool, error) {
+	var count int64
+	err := r.db.Table(entityType).Where("slug = ? AND id <> ?", slug, entityID).Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	err = r.db.Model(&model.SlugRedirect{}).
+		Where("entity_type = ? AND slug = ? AND entity_id <> ?", entityType, slug, entityID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// recordSlugChange keeps the old slug as a redirect and drops a slug taken back from the history
+func recordSlugChange(tx *gorm.DB, entityType string, entityID uint64, oldSlug, newSlug string) error {
+	if oldSlug == newSlug {
+		return nil
+	}
+	if err := tx.Where("entity_type = ? AND slug = ?", entityType, newSlug).Delete(&model.SlugRedirect{}).Error; err != nil {
+		return err
+	}
+	if oldSlug == "" {
+		return nil
+	}
+
+	redirect := model.SlugRedirect{EntityType: entityType, Slug: oldSlug, EntityID: entityID}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entity_type"}, {Name: "slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"entity_id"}),
+	}).Create(&redirect).Error
+}
+
+func deleteSlugRedirects(tx *gorm.DB, entityType string, entityID uint64) error {
+	return tx.Where("entity_type = ? AND entity_id = ?", entityType, entityID).Delete(&model.SlugRedirect{}).Error
+}
@@ -1 +1,104 @@
 package repository
+
+import (
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+type UserRepository interface {
+	Create(user *model.User) error
+	GetByID(id uint64) (*model.User, error)
+	GetByEmail(email string) (*model.User, error)
+	GetByPhone(phone string) (*model.User, error)
+	ExistsByEmail(email string) (bool, error)
+	Update(user *model.User) error
+	UpdateLastLogin(id uint64, at time.Time) error
+	UpdatePassword(id uint64, passwordHash string) error
+	MarkEmailVerified(id uint64, at time.Time) error
+	MarkPhoneVerified(id uint64, at time.Time) error
+	UpdateTOTP(id uint64, secret string, enabledAt *time.Time, lastStep int64) error
+	AdvanceTOTPStep(id uint64, step int64) (bool, error)
+}
+
+type userRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+func (r *userRepository) Create(user *model.User) error {
+	return r.db.Create(user).Error
+}
+
+func (r *userRepository) GetByID(id uint64) (*model.User, error) {
+	var user model.User
+	err := r.db.First(&user, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) GetByEmail(email string) (*model.User, error) {
+	var user model.User
+	err := r.db.Where("email = ?", email).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) GetByPhone(phone string) (*model.User, error) {
+	var user model.User
+	err := r.db.Where("phone = ?", phone).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) ExistsByEmail(email string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.User{}).Where("email = ?", email).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *userRepository) Update(user *model.User) error {
+	return r.db.Save(user).Error
+}
+
+func (r *userRepository) UpdateLastLogin(id uint64, at time.Time) error {
+	return r.db.Model(&model.User{}).Where("id = ?", id).Update("last_login_at", at).Error
+}
+
+func (r *userRepository) UpdatePassword(id uint64, passwordHash string) error {
+	return r.db.Model(&model.User{}).Where("id = ?", id).Update("password_hash", passwordHash).Error
+}
+
+func (r *userRepository) MarkEmailVerified(id uint64, at time.Time) error {
+	return r.db.Model(&model.User{}).Where("id = ?", id).Update("email_verified_at", at).Error
+}
+
+func (r *userRepository) MarkPhoneVerified(id uint64, at time.Time) error {
+	return r.db.Model(&model.User{}).Where("id = ?", id).Update("phone_verified_at", at).Error
+}
+
+func (r *userRepository) UpdateTOTP(id uint64, secret string, enabledAt *time.Time, lastStep int64) error {
+	return r.db.Model(&model.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"totp_secret":     secret,
+		"totp_enabled_at": enabledAt,
+		"totp_last_step":  lastStep,
+	}).Error
+}
+
+// AdvanceTOTPStep records an accepted code's time step, failing unless it is newer than the last
+func (r *userRepository) AdvanceTOTPStep(id uint64, step int64) (bool, error) {
+	result := r.db.Model(&model.User{}).
+		Where("id = ? AND totp_last_step < ?", id, step).
+		Update("totp_last_step", step)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"gorm.io/gorm"
+)
+
+// ErrTokenAlreadyUsed is returned when a single-use token was already consumed
+var ErrTokenAlreadyUsed = errors.New("token already used")
+
+type UserTokenRepository interface {
+	Create(token *model.UserToken) error
+	GetByHash(purpose, tokenHash string) (*model.UserToken, error)
+	MarkUsed(id uint64) error
+	InvalidateForUser(userID uint64, purpose string) error
+}
+
+type userTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &userTokenRepository{db: db}
+}
+
+func (r *userTokenRepository) Create(token *model.UserToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *userTokenRepository) GetByHash(purpose, tokenHash string) (*model.UserToken, error) {
+	var token model.UserToken
+	err := r.db.Where("purpose = ? AND token_hash = ?", purpose, tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *userTokenRepository) MarkUsed(id uint64) error {
+	result := r.db.Model(&model.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTokenAlreadyUsed
+	}
+	return nil
+}
+
+// InvalidateForUser supersedes every outstanding token of the given purpose
+func (r *userTokenRepository) InvalidateForUser(userID uint64, purpose string) error {
+	return r.db.Model(&model.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
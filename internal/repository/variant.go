@@ -2,20 +2,37 @@ package repository
 
 import (
 	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type VariantRepository interface {
 	Create(variant *model.Variant) error
 	GetByID(id uint64) (*model.Variant, error)
 	GetByProductID(productID uint64) ([]model.Variant, error)
-	GetByProductIDWithPagination(productID uint64, page, limit int) ([]model.Variant, int64, error)
+	GetByProductIDWithPagination(productID uint64, spec pagination.QuerySpec, params pagination.PaginationParams) ([]model.Variant, pagination.PageInfo, error)
 	GetBySKU(sku string) (*model.Variant, error)
 	GetActiveByProductID(productID uint64) ([]model.Variant, error)
-	GetActiveByProductIDWithPagination(productID uint64, page, limit int) ([]model.Variant, int64, error)
+	GetActiveByProductIDWithPagination(productID uint64, spec pagination.QuerySpec, params pagination.PaginationParams) ([]model.Variant, pagination.PageInfo, error)
 	Update(variant *model.Variant) error
 	Delete(id uint64) error
 	UpdateStock(id uint64, quantity int) error
+	GetOptionKeys(productID uint64) ([]string, error)
+	FindExistingSKUs(skus []string) ([]string, error)
+	CreateWithOptions(variants []model.Variant) error
+	SetOptions(variantID uint64, optionKey string, links []model.VariantOptionValue) error
+}
+
+// variantQueryFields are the variant fields clients may sort and filter by
+var variantQueryFields = pagination.Fields{
+	"id":            {Column: "id", Type: pagination.FieldInteger},
+	"sku":           {Column: "sku", Type: pagination.FieldString},
+	"price":         {Column: "price", Type: pagination.FieldNumber},
+	"stockQuantity": {Column: "stock_quantity", Type: pagination.FieldInteger},
+	"isActive":      {Column: "is_active", Type: pagination.FieldBoolean},
+	"createdAt":     {Column: "created_at", Type: pagination.FieldTime},
+	"updatedAt":     {Column: "updated_at", Type: pagination.FieldTime},
 }
 
 type variantRepository struct {
@@ -32,7 +49,7 @@ func (r *variantRepository) Create(variant *model.Variant) error {
 
 func (r *variantRepository) GetByID(id uint64) (*model.Variant, error) {
 	var variant model.Variant
-	err := r.db.Preload("Product").Preload("Media").First(&variant, id).Error
+	err := r.db.Preload("Product").Preload("Media").Preload("OptionValues.OptionValue").First(&variant, id).Error
 	return &variant, err
 }
 
@@ -42,23 +59,8 @@ func (r *variantRepository) GetByProductID(productID uint64) ([]model.Variant, e
 	return variants, err
 }
 
-func (r *variantRepository) GetByProductIDWithPagination(productID uint64, page, limit int) ([]model.Variant, int64, error) {
-	var variants []model.Variant
-	var total int64
-
-	// Get total count for this product
-	if err := r.db.Model(&model.Variant{}).Where("product_id = ?", productID).Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// Get paginated results using database-level pagination
-	offset := (page - 1) * limit
-	err := r.db.Where("product_id = ?", productID).
-		Limit(limit).
-		Offset(offset).
-		Find(&variants).Error
-
-	return variants, total, err
+func (r *variantRepository) GetByProductIDWithPagination(productID uint64, spec pagination.QuerySpec, params pagination.PaginationParams) ([]model.Variant, pagination.PageInfo, error) {
+	return r.findWithPagination(spec, params, "product_id = ?", productID)
 }
 
 func (r *variantRepository) GetBySKU(sku string) (*model.Variant, error) {
@@ -73,33 +75,82 @@ func (r *variantRepository) GetActiveByProductID(productID uint64) ([]model.Vari
 	return variants, err
 }
 
-func (r *variantRepository) GetActiveByProductIDWithPagination(productID uint64, page, limit int) ([]model.Variant, int64, error) {
-	var variants []model.Variant
-	var total int64
+func (r *variantRepository) GetActiveByProductIDWithPagination(productID uint64, spec pagination.QuerySpec, params pagination.PaginationParams) ([]model.Variant, pagination.PageInfo, error) {
+	return r.findWithPagination(spec, params, "product_id = ? AND is_active = ?", productID, true)
+}
 
-	// Get total count for active variants of this product
-	if err := r.db.Model(&model.Variant{}).Where("product_id = ? AND is_active = ?", productID, true).Count(&total).Error; err != nil {
-		return nil, 0, err
+// findWithPagination sorts oldest first by default
+func (r *variantRepository) findWithPagination(spec pagination.QuerySpec, params pagination.PaginationParams, query string, args ...any) ([]model.Variant, pagination.PageInfo, error) {
+	var variants []model.Variant
+	filtered := func(db *gorm.DB) *gorm.DB {
+		return db.Model(&model.Variant{}).Where(query, args...).Scopes(withFilters("variant", variantQueryFields, spec.Filters))
 	}
-
-	// Get paginated results using database-level pagination
-	offset := (page - 1) * limit
-	err := r.db.Where("product_id = ? AND is_active = ?", productID, true).
-		Limit(limit).
-		Offset(offset).
-		Find(&variants).Error
-
-	return variants, total, err
+	list := pageQuery{table: "variant", fields: variantQueryFields, sort: spec.Sort}
+	info, err := findPage(r.db, filtered, list, params, &variants, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("OptionValues.OptionValue")
+	})
+	return variants, info, err
 }
 
+// Update leaves the options to SetOptions
 func (r *variantRepository) Update(variant *model.Variant) error {
-	return r.db.Save(variant).Error
+	return r.db.Omit("option_key", clause.Associations).Save(variant).Error
 }
 
 func (r *variantRepository) Delete(id uint64) error {
-	return r.db.Delete(&model.Variant{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("variant_id = ?", id).Delete(&model.VariantOptionValue{}).Error; err != nil {
+			return err
+		}
+		if err := deleteMetafields(tx, model.MetafieldOwnerVariant, id); err != nil {
+			return err
+		}
+		return tx.Delete(&model.Variant{}, id).Error
+	})
 }
 
 func (r *variantRepository) UpdateStock(id uint64, quantity int) error {
 	return r.db.Model(&model.Variant{}).Where("id = ?", id).Update("stock_quantity", quantity).Error
 }
+
+func (r *variantRepository) GetOptionKeys(productID uint64) ([]string, error) {
+	var keys []string
+	err := r.db.Model(&model.Variant{}).
+		Where("product_id = ? AND option_key <> ''", productID).
+		Pluck("option_key", &keys).Error
+	return keys, err
+}
+
+func (r *variantRepository) FindExistingSKUs(skus []string) ([]string, error) {
+	var existing []string
+	if len(skus) == 0 {
+		return existing, nil
+	}
+	err := r.db.Model(&model.Variant{}).Where("sku IN ?", skus).Pluck("sku", &existing).Error
+	return existing, err
+}
+
+// CreateWithOptions creates every variant or none
+func (r *variantRepository) CreateWithOptions(variants []model.Variant) error {
+	if len(variants) == 0 {
+		return nil
+	}
+	return r.db.Create(&variants).Error
+}
+
+func (r *variantRepository) SetOptions(variantID uint64, optionKey string, links []model.VariantOptionValue) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("variant_id = ?", variantID).Delete(&model.VariantOptionValue{}).Error; err != nil {
+			return err
+		}
+		for i := range links {
+			links[i].VariantID = variantID
+		}
+		if len(links) > 0 {
+			if err := tx.Create(&links).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&model.Variant{}).Where("id = ?", variantID).Update("option_key", optionKey).Error
+	})
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"github.com/Durgarao310/zneha-backend/internal/api/controller"
 	"github.com/Durgarao310/zneha-backend/internal/api/middleware"
+	"github.com/Durgarao310/zneha-backend/internal/model"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,57 +12,229 @@ func RegisterRoutes(router *gin.Engine,
 	productController controller.ProductController,
 	categoryController *controller.CategoryController,
 	mediaController *controller.MediaController,
-	variantController *controller.VariantController) {
+	variantController *controller.VariantController,
+	authController *controller.AuthController,
+	roleController *controller.RoleController,
+	twoFactorController *controller.TwoFactorController,
+	lockoutController *controller.LockoutController,
+	apiKeyController *controller.APIKeyController,
+	addressController *controller.AddressController,
+	accountController *controller.AccountController,
+	optionController *controller.OptionController,
+	attributeController *controller.AttributeController,
+	metafieldController *controller.MetafieldController,
+	brandController *controller.BrandController,
+	searchController *controller.SearchController,
+	authMiddleware *middleware.AuthMiddleware) {
 	api := router.Group("/api/v1")
 	api.Use(middleware.ValidationMiddleware())
+
+	requireAuth := authMiddleware.RequireAuth()
 	{
+		// Auth routes
+		auth := api.Group("/auth")
+		{
+			auth.POST("/register", authController.Register)
+			auth.POST("/login", authController.Login)
+			auth.POST("/refresh", authController.Refresh)
+			auth.POST("/logout", authController.Logout)
+			auth.POST("/logout-all", requireAuth, authController.LogoutAll)
+			auth.POST("/password/forgot", authController.ForgotPassword)
+			auth.POST("/password/reset", authController.ResetPassword)
+			auth.POST("/email/verify", authController.VerifyEmail)
+			auth.POST("/email/resend", requireAuth, authController.ResendVerification)
+			auth.GET("/me", requireAuth, authController.Me)
+		}
+
+		// Phone OTP login routes
+		otp := auth.Group("/otp")
+		{
+			otp.POST("/request", authController.RequestPhoneOTP)
+			otp.POST("/verify", authController.VerifyPhoneOTP)
+		}
+
+		// Address book routes, scoped to the authenticated user
+		addresses := api.Group("/addresses", requireAuth)
+		{
+			addresses.GET("", addressController.List)
+			addresses.POST("", addressController.Create)
+			addresses.GET("/:id", addressController.Get)
+			addresses.PUT("/:id", addressController.Update)
+			addresses.DELETE("/:id", addressController.Delete)
+		}
+
+		// Personal data export and account deletion
+		account := api.Group("/account", requireAuth)
+		{
+			account.GET("/export", accountController.Export)
+			account.DELETE("", accountController.Delete)
+		}
+
+		// Two-factor authentication routes
+		twoFactor := auth.Group("/2fa")
+		{
+			twoFactor.POST("/verify", authController.VerifyMFA)
+			twoFactor.POST("/enroll", requireAuth, twoFactorController.Enroll)
+			twoFactor.POST("/confirm", requireAuth, twoFactorController.Confirm)
+			twoFactor.POST("/disable", requireAuth, twoFactorController.Disable)
+			twoFactor.POST("/recovery-codes", requireAuth, twoFactorController.RegenerateRecoveryCodes)
+		}
+
 		// Products routes
 		products := api.Group("/products")
 		{
-			products.POST("/", productController.Create)
 			products.GET("/", productController.GetAll)
+			products.GET("/search", productController.Search)
+			products.GET("/slug/:slug", productController.GetBySlug)
 			products.GET("/:id", productController.GetByID)
-			products.PUT("/:id", productController.Update)
-			products.DELETE("/:id", productController.Delete)
+			products.GET("/:id/seo", productController.GetSEO)
+			products.GET("/:id/options", optionController.ListOptions)
+		}
+		productsWrite := products.Group("", requireAuth, authMiddleware.RequirePermission(model.PermCatalogWrite))
+		{
+			productsWrite.POST("/", productController.Create)
+			productsWrite.PUT("/:id", productController.Update)
+			productsWrite.DELETE("/:id", productController.Delete)
+			productsWrite.POST("/:id/options", optionController.CreateOption)
+			productsWrite.PUT("/:id/options/order", optionController.ReorderOptions)
+			productsWrite.PUT("/:id/options/:optionId", optionController.UpdateOption)
+			productsWrite.DELETE("/:id/options/:optionId", optionController.DeleteOption)
+			productsWrite.POST("/:id/options/:optionId/values", optionController.AddValue)
+			productsWrite.PUT("/:id/options/:optionId/values/order", optionController.ReorderValues)
+			productsWrite.DELETE("/:id/options/:optionId/values/:valueId", optionController.DeleteValue)
+			productsWrite.POST("/:id/variants/generate", optionController.GenerateVariants)
+			productsWrite.PUT("/:id/attributes", attributeController.SetProductAttributes)
 		}
 
 		// Categories routes
 		categories := api.Group("/categories")
 		{
-			categories.POST("/", categoryController.CreateCategory)
 			categories.GET("/", categoryController.GetAllCategories)
 			categories.GET("/root", categoryController.GetRootCategories)
+			categories.GET("/tree", categoryController.GetTree)
+			categories.GET("/slug/:slug", categoryController.GetCategoryBySlug)
 			categories.GET("/:id", categoryController.GetCategory)
 			categories.GET("/:id/subcategories", categoryController.GetSubcategories)
-			categories.PUT("/:id", categoryController.UpdateCategory)
-			categories.DELETE("/:id", categoryController.DeleteCategory)
+			categories.GET("/:id/products", categoryController.GetCategoryProducts)
+			categories.GET("/:id/ancestors", categoryController.GetAncestors)
+			categories.GET("/:id/descendants", categoryController.GetDescendants)
+			categories.GET("/:id/attributes", attributeController.GetCategoryAttributes)
+		}
+		categoriesWrite := categories.Group("", requireAuth, authMiddleware.RequirePermission(model.PermCatalogWrite))
+		{
+			categoriesWrite.POST("/", categoryController.CreateCategory)
+			categoriesWrite.PUT("/reorder", categoryController.ReorderCategories)
+			categoriesWrite.PUT("/:id", categoryController.UpdateCategory)
+			categoriesWrite.PUT("/:id/move", categoryController.MoveCategory)
+			categoriesWrite.DELETE("/:id", categoryController.DeleteCategory)
+			categoriesWrite.PUT("/:id/attributes", attributeController.SetCategoryAttributes)
 		}
 
+		// Brands routes
+		brands := api.Group("/brands")
+		{
+			brands.GET("/", brandController.ListBrands)
+			brands.GET("/slug/:slug", brandController.GetBrandBySlug)
+			brands.GET("/:id", brandController.GetBrand)
+			brands.GET("/:id/products", brandController.GetBrandProducts)
+		}
+		brandsWrite := brands.Group("", requireAuth, authMiddleware.RequirePermission(model.PermCatalogWrite))
+		{
+			brandsWrite.POST("/", brandController.CreateBrand)
+			brandsWrite.PUT("/:id", brandController.UpdateBrand)
+			brandsWrite.DELETE("/:id", brandController.DeleteBrand)
+		}
+
+		// Search box autocomplete over product, category and brand names
+		api.GET("/search/suggest", searchController.Suggest)
+
+		// Attribute definitions routes
+		attributes := api.Group("/attributes")
+		{
+			attributes.GET("/", attributeController.ListAttributes)
+			attributes.GET("/:id", attributeController.GetAttribute)
+		}
+		attributesWrite := attributes.Group("", requireAuth, authMiddleware.RequirePermission(model.PermCatalogWrite))
+		{
+			attributesWrite.POST("/", attributeController.CreateAttribute)
+			attributesWrite.PUT("/:id", attributeController.UpdateAttribute)
+			attributesWrite.DELETE("/:id", attributeController.DeleteAttribute)
+		}
+
+		// Public metafields of any resource, e.g. /metafields/product/12
+		api.GET("/metafields/:ownerType/:ownerId", metafieldController.ListPublic)
+
 		// Media routes
 		media := api.Group("/media")
 		{
-			media.POST("/", mediaController.CreateMedia)
 			media.GET("/:id", mediaController.GetMedia)
-			media.PUT("/:id", mediaController.UpdateMedia)
-			media.DELETE("/:id", mediaController.DeleteMedia)
 			media.GET("/product/:productId", mediaController.GetMediaByProduct)
 			media.GET("/variant/:variantId", mediaController.GetMediaByVariant)
-			media.PUT("/product/:productId/primary/:mediaId", mediaController.SetPrimaryMedia)
+		}
+		mediaWrite := media.Group("", requireAuth, authMiddleware.RequirePermission(model.PermMediaWrite))
+		{
+			mediaWrite.POST("/", mediaController.CreateMedia)
+			mediaWrite.PUT("/:id", mediaController.UpdateMedia)
+			mediaWrite.DELETE("/:id", mediaController.DeleteMedia)
+			mediaWrite.PUT("/product/:productId/primary/:mediaId", mediaController.SetPrimaryMedia)
 		}
 
 		// Variants routes
 		variants := api.Group("/variants")
 		{
-			variants.POST("/", variantController.CreateVariant)
 			variants.GET("/:id", variantController.GetVariant)
 			variants.GET("/sku/:sku", variantController.GetVariantBySKU)
 			variants.GET("/product/:productId", variantController.GetVariantsByProduct)
 			variants.GET("/product/:productId/active", variantController.GetActiveVariantsByProduct)
-			variants.PUT("/:id", variantController.UpdateVariant)
-			variants.PUT("/:id/stock", variantController.UpdateStock)
-			variants.PUT("/:id/activate", variantController.ActivateVariant)
-			variants.PUT("/:id/deactivate", variantController.DeactivateVariant)
-			variants.DELETE("/:id", variantController.DeleteVariant)
+		}
+		variantsWrite := variants.Group("", requireAuth, authMiddleware.RequirePermission(model.PermCatalogWrite))
+		{
+			variantsWrite.POST("/", variantController.CreateVariant)
+			variantsWrite.PUT("/:id", variantController.UpdateVariant)
+			variantsWrite.DELETE("/:id", variantController.DeleteVariant)
+			variantsWrite.PUT("/:id/options", optionController.SetVariantOptions)
+		}
+		inventoryWrite := variants.Group("", requireAuth, authMiddleware.RequirePermission(model.PermInventoryWrite))
+		{
+			inventoryWrite.PUT("/:id/stock", variantController.UpdateStock)
+			inventoryWrite.PUT("/:id/activate", variantController.ActivateVariant)
+			inventoryWrite.PUT("/:id/deactivate", variantController.DeactivateVariant)
+		}
+
+		// Admin routes
+		admin := api.Group("/admin", requireAuth)
+		usersAdmin := admin.Group("", authMiddleware.RequirePermission(model.PermUsersManage))
+		{
+			usersAdmin.GET("/permissions", roleController.ListPermissions)
+			usersAdmin.GET("/roles", roleController.ListRoles)
+			usersAdmin.POST("/roles", roleController.CreateRole)
+			usersAdmin.GET("/roles/:id", roleController.GetRole)
+			usersAdmin.PUT("/roles/:id", roleController.UpdateRole)
+			usersAdmin.DELETE("/roles/:id", roleController.DeleteRole)
+			usersAdmin.GET("/users/:id/roles", roleController.GetUserRoles)
+			usersAdmin.POST("/users/:id/roles", roleController.AssignRole)
+			usersAdmin.DELETE("/users/:id/roles/:roleId", roleController.RevokeRole)
+			usersAdmin.POST("/users/:id/sessions/revoke", authController.RevokeUserSessions)
+			usersAdmin.DELETE("/sessions/:sessionId", authController.RevokeSession)
+			usersAdmin.POST("/users/:id/unlock", lockoutController.UnlockUser)
+			usersAdmin.GET("/lockouts", lockoutController.ListLockouts)
+		}
+		metafieldsAdmin := admin.Group("", authMiddleware.RequirePermission(model.PermCatalogWrite))
+		{
+			metafieldsAdmin.GET("/metafield-definitions", metafieldController.ListDefinitions)
+			metafieldsAdmin.POST("/metafield-definitions", metafieldController.CreateDefinition)
+			metafieldsAdmin.GET("/metafield-definitions/:id", metafieldController.GetDefinition)
+			metafieldsAdmin.PUT("/metafield-definitions/:id", metafieldController.UpdateDefinition)
+			metafieldsAdmin.DELETE("/metafield-definitions/:id", metafieldController.DeleteDefinition)
+			metafieldsAdmin.GET("/metafields/:ownerType/:ownerId", metafieldController.ListAll)
+			metafieldsAdmin.PUT("/metafields/:ownerType/:ownerId/:namespace/:key", metafieldController.SetMetafield)
+			metafieldsAdmin.DELETE("/metafields/:ownerType/:ownerId/:namespace/:key", metafieldController.DeleteMetafield)
+		}
+		apiKeysAdmin := admin.Group("/api-keys", authMiddleware.RequirePermission(model.PermAPIKeysManage))
+		{
+			apiKeysAdmin.GET("", apiKeyController.ListKeys)
+			apiKeysAdmin.POST("", apiKeyController.CreateKey)
+			apiKeysAdmin.DELETE("/:id", apiKeyController.RevokeKey)
 		}
 	}
 }
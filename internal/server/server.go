@@ -6,6 +6,7 @@ import (
 	"github.com/Durgarao310/zneha-backend/pkg/api"
 	"github.com/Durgarao310/zneha-backend/pkg/logger"
 	pkgMiddleware "github.com/Durgarao310/zneha-backend/pkg/middleware"
+	"github.com/Durgarao310/zneha-backend/pkg/validator"
 
 	"github.com/gin-gonic/gin"
 )
@@ -34,6 +35,11 @@ func (s *Server) SetupRouter() {
 		s.logger.Error("Failed to set trusted proxies", "error", err)
 	}
 
+	// Register custom validation tags used by request DTOs
+	if err := validator.RegisterCustomValidators(); err != nil {
+		s.logger.Error("Failed to register custom validators", "error", err)
+	}
+
 	// Apply middleware
 	s.setupMiddleware()
 
@@ -61,6 +67,19 @@ func (s *Server) registerRoutes() {
 		s.container.CategoryController,
 		s.container.MediaController,
 		s.container.VariantController,
+		s.container.AuthController,
+		s.container.RoleController,
+		s.container.TwoFactorController,
+		s.container.LockoutController,
+		s.container.APIKeyController,
+		s.container.AddressController,
+		s.container.AccountController,
+		s.container.OptionController,
+		s.container.AttributeController,
+		s.container.MetafieldController,
+		s.container.BrandController,
+		s.container.SearchController,
+		s.container.AuthMiddleware,
 	)
 }
 
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"github.com/Durgarao310/zneha-backend/pkg/hash"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPasswordRequired      = errors.New("current password is required")
+	ErrTwoFactorCodeRequired = errors.New("two-factor code is required")
+)
+
+type AccountService interface {
+	ExportData(userID uint64) (*repository.AccountData, error)
+	DeleteAccount(userID uint64, password, code string, client ClientInfo) error
+}
+
+type accountService struct {
+	accountRepo      repository.AccountRepository
+	userRepo         repository.UserRepository
+	twoFactorService TwoFactorService
+	loginThrottle    LoginThrottleService
+}
+
+func NewAccountService(
+	accountRepo repository.AccountRepository,
+	userRepo repository.UserRepository,
+	twoFactorService TwoFactorService,
+	loginThrottle LoginThrottleService,
+) AccountService {
+	return &accountService{
+		accountRepo:      accountRepo,
+		userRepo:         userRepo,
+		twoFactorService: twoFactorService,
+		loginThrottle:    loginThrottle,
+	}
+}
+
+func (s *accountService) ExportData(userID uint64) (*repository.AccountData, error) {
+	data, err := s.accountRepo.Collect(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return data, err
+}
+
+// DeleteAccount requires the password and two-factor code the account has; wrong ones count as failed logins
+func (s *accountService) DeleteAccount(userID uint64, password, code string, client ClientInfo) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	key := loginKey(user)
+
+	if user.PasswordHash != "" && strings.TrimSpace(password) == "" {
+		return ErrPasswordRequired
+	}
+	if user.TOTPEnabledAt != nil && strings.TrimSpace(code) == "" {
+		return ErrTwoFactorCodeRequired
+	}
+	if err := s.loginThrottle.Check(key, client.IPAddress); err != nil {
+		return err
+	}
+
+	if user.PasswordHash != "" && !hash.CheckPassword(user.PasswordHash, password) {
+		return s.failed(key, client, user.ID, ErrInvalidCredentials)
+	}
+	if user.TOTPEnabledAt != nil {
+		ok, err := s.twoFactorService.VerifyCode(user, code)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return s.failed(key, client, user.ID, ErrInvalidTwoFactorCode)
+		}
+	}
+
+	err = s.accountRepo.Erase(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	return err
+}
+
+func (s *accountService) failed(key string, client ClientInfo, userID uint64, cause error) error {
+	if err := s.loginThrottle.RecordFailure(key, client.IPAddress, &userID); err != nil {
+		return err
+	}
+	return cause
+}
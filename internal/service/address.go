@@ -0,0 +1,119 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"github.com/Durgarao310/zneha-backend/pkg/validator"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAddressNotFound   = errors.New("address not found")
+	ErrAddressLimit      = errors.New("address book is full")
+	ErrInvalidAddressPin = errors.New("invalid pincode")
+)
+
+const maxAddressesPerUser = 20
+
+type AddressService interface {
+	ListAddresses(userID uint64) ([]model.Address, error)
+	GetAddress(userID, id uint64) (*model.Address, error)
+	CreateAddress(userID uint64, address *model.Address) error
+	UpdateAddress(userID, id uint64, address *model.Address) (*model.Address, error)
+	DeleteAddress(userID, id uint64) error
+}
+
+type addressService struct {
+	addressRepo repository.AddressRepository
+}
+
+func NewAddressService(addressRepo repository.AddressRepository) AddressService {
+	return &addressService{
+		addressRepo: addressRepo,
+	}
+}
+
+func (s *addressService) ListAddresses(userID uint64) ([]model.Address, error) {
+	return s.addressRepo.GetByUser(userID)
+}
+
+func (s *addressService) GetAddress(userID, id uint64) (*model.Address, error) {
+	address, err := s.addressRepo.GetForUser(id, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAddressNotFound
+		}
+		return nil, err
+	}
+	return address, nil
+}
+
+// The first address becomes the default for both shipping and billing
+func (s *addressService) CreateAddress(userID uint64, address *model.Address) error {
+	count, err := s.addressRepo.CountByUser(userID)
+	if err != nil {
+		return err
+	}
+	if count >= maxAddressesPerUser {
+		return ErrAddressLimit
+	}
+
+	if err := normalizeAddress(address); err != nil {
+		return err
+	}
+	address.ID = 0
+	address.UserID = userID
+	if count == 0 {
+		address.IsDefaultShipping = true
+		address.IsDefaultBilling = true
+	}
+	return s.addressRepo.Save(address)
+}
+
+func (s *addressService) UpdateAddress(userID, id uint64, address *model.Address) (*model.Address, error) {
+	existing, err := s.GetAddress(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := normalizeAddress(address); err != nil {
+		return nil, err
+	}
+	address.ID = existing.ID
+	address.UserID = existing.UserID
+	address.CreatedAt = existing.CreatedAt
+	// A default can only be moved to another address, not cleared
+	address.IsDefaultShipping = address.IsDefaultShipping || existing.IsDefaultShipping
+	address.IsDefaultBilling = address.IsDefaultBilling || existing.IsDefaultBilling
+
+	if err := s.addressRepo.Save(address); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+func (s *addressService) DeleteAddress(userID, id uint64) error {
+	err := s.addressRepo.Delete(id, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrAddressNotFound
+	}
+	return err
+}
+
+// normalizeAddress stores the phone in E.164 and defaults the country to India
+func normalizeAddress(address *model.Address) error {
+	phone, ok := validator.NormalizeIndianPhone(address.Phone)
+	if !ok {
+		return ErrInvalidPhone
+	}
+	if !validator.IsIndianPincode(address.Pincode) {
+		return ErrInvalidAddressPin
+	}
+	address.Phone = phone
+	if address.Country == "" {
+		address.Country = "IN"
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"github.com/Durgarao310/zneha-backend/pkg/hash"
+)
+
+var (
+	ErrInvalidAPIKey  = errors.New("invalid, expired or revoked API key")
+	ErrAPIKeyNotFound = errors.New("API key not found")
+	ErrScopeNotHeld   = errors.New("cannot grant a scope you do not hold")
+	ErrAPIKeyExpired  = errors.New("API key expiry must be in the future")
+)
+
+const (
+	apiKeyPrefix       = "zk_"
+	apiKeySecretBytes  = 32
+	apiKeyTouchEvery   = time.Minute // minimum interval between last-used updates
+	apiKeyPrefixLength = 8
+)
+
+type APIKeyService interface {
+	CreateKey(name string, scopes []string, expiresAt *time.Time, createdByID *uint64, granted map[string]bool) (*model.APIKey, string, error)
+	ListKeys() ([]model.APIKey, error)
+	RevokeKey(id uint64) error
+	Authenticate(rawKey string) (*model.APIKey, error)
+}
+
+type apiKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+}
+
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// CreateKey returns the only plain text copy of the key. Scopes must be held by the creator.
+func (s *apiKeyService) CreateKey(name string, scopes []string, expiresAt *time.Time, createdByID *uint64, granted map[string]bool) (*model.APIKey, string, error) {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", ErrAPIKeyExpired
+	}
+
+	unique := make([]string, 0, len(scopes))
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		if _, ok := model.AllPermissions[scope]; !ok {
+			return nil, "", fmt.Errorf("%w: %s", ErrUnknownPermission, scope)
+		}
+		if !granted[scope] {
+			return nil, "", fmt.Errorf("%w: %s", ErrScopeNotHeld, scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+
+	prefixBytes := make([]byte, apiKeyPrefixLength/2)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return nil, "", err
+	}
+	secret, err := hash.GenerateToken(apiKeySecretBytes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	prefix := apiKeyPrefix + hex.EncodeToString(prefixBytes)
+	plain := prefix + "_" + secret
+
+	key := &model.APIKey{
+		Name:        strings.TrimSpace(name),
+		Prefix:      prefix,
+		KeyHash:     hash.SHA256(plain),
+		Scopes:      unique,
+		CreatedByID: createdByID,
+		ExpiresAt:   expiresAt,
+	}
+	if err := s.apiKeyRepo.Create(key); err != nil {
+		return nil, "", err
+	}
+	return key, plain, nil
+}
+
+func (s *apiKeyService) ListKeys() ([]model.APIKey, error) {
+	return s.apiKeyRepo.GetAll()
+}
+
+func (s *apiKeyService) RevokeKey(id uint64) error {
+	if _, err := s.apiKeyRepo.GetByID(id); err != nil {
+		return ErrAPIKeyNotFound
+	}
+	return s.apiKeyRepo.Revoke(id)
+}
+
+func (s *apiKeyService) Authenticate(rawKey string) (*model.APIKey, error) {
+	if !strings.HasPrefix(rawKey, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.apiKeyRepo.GetByHash(hash.SHA256(rawKey))
+	if err != nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil || (key.ExpiresAt != nil && now.After(*key.ExpiresAt)) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchEvery {
+		if err := s.apiKeyRepo.TouchLastUsed(key.ID, now); err != nil {
+			return nil, err
+		}
+		key.LastUsedAt = &now
+	}
+	return key, nil
+}
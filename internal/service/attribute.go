@@ -0,0 +1,372 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAttributeNotFound        = errors.New("attribute not found")
+	ErrDuplicateAttribute       = errors.New("attribute code or option already exists")
+	ErrInvalidAttributeCode     = errors.New("attribute code must start with a letter and contain only lowercase letters, digits and underscores")
+	ErrInvalidAttributeType     = errors.New("attribute type must be text, number, boolean or enum")
+	ErrEnumOptionsRequired      = errors.New("enum attributes need at least one option")
+	ErrAttributeOptionInUse     = errors.New("attribute option is used by existing products")
+	ErrUnknownAttribute         = errors.New("attribute is not assigned to any of the product's categories")
+	ErrInvalidAttributeValue    = errors.New("attribute value does not match the attribute type")
+	ErrRequiredAttributeMissing = errors.New("required attribute is missing")
+)
+
+// maxAttributeTextLength matches the text value column
+const maxAttributeTextLength = 1000
+
+var validAttributeCode = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type AttributeService interface {
+	ListAttributes() ([]model.Attribute, error)
+	GetAttribute(id uint64) (*model.Attribute, error)
+	CreateAttribute(attribute *model.Attribute) error
+	UpdateAttribute(attribute *model.Attribute) error
+	DeleteAttribute(id uint64) error
+	GetCategoryAttributes(categoryID uint64) ([]model.CategoryAttribute, error)
+	SetCategoryAttributes(categoryID uint64, links []model.CategoryAttribute) ([]model.CategoryAttribute, error)
+	SetProductAttributes(productID uint64, values map[string]any) (*model.Product, error)
+}
+
+type attributeService struct {
+	attributeRepo repository.AttributeRepository
+	categoryRepo  repository.CategoryRepository
+	productRepo   repository.ProductRepository
+}
+
+func NewAttributeService(attributeRepo repository.AttributeRepository, categoryRepo repository.CategoryRepository, productRepo repository.ProductRepository) AttributeService {
+	return &attributeService{
+		attributeRepo: attributeRepo,
+		categoryRepo:  categoryRepo,
+		productRepo:   productRepo,
+	}
+}
+
+func (s *attributeService) ListAttributes() ([]model.Attribute, error) {
+	return s.attributeRepo.GetAll()
+}
+
+func (s *attributeService) GetAttribute(id uint64) (*model.Attribute, error) {
+	attribute, err := s.attributeRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAttributeNotFound
+		}
+		return nil, err
+	}
+	return attribute, nil
+}
+
+func (s *attributeService) CreateAttribute(attribute *model.Attribute) error {
+	attribute.Code = strings.ToLower(strings.TrimSpace(attribute.Code))
+	if !validAttributeCode.MatchString(attribute.Code) {
+		return ErrInvalidAttributeCode
+	}
+	switch attribute.Type {
+	case model.AttributeTypeText, model.AttributeTypeNumber, model.AttributeTypeBoolean, model.AttributeTypeEnum:
+	default:
+		return ErrInvalidAttributeType
+	}
+	if err := normalizeAttribute(attribute); err != nil {
+		return err
+	}
+
+	_, err := s.attributeRepo.GetByCode(attribute.Code)
+	if err == nil {
+		return ErrDuplicateAttribute
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if err := s.attributeRepo.Create(attribute); err != nil {
+		if repository.IsUniqueViolation(err, "idx_attribute_code") {
+			return ErrDuplicateAttribute
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateAttribute keeps the code and type, which stored values depend on. Options
+// are matched by value, so renaming one is refused while products use it.
+func (s *attributeService) UpdateAttribute(attribute *model.Attribute) error {
+	current, err := s.GetAttribute(attribute.ID)
+	if err != nil {
+		return err
+	}
+	attribute.Code = current.Code
+	attribute.Type = current.Type
+	attribute.CreatedAt = current.CreatedAt
+	if err := normalizeAttribute(attribute); err != nil {
+		return err
+	}
+
+	existing := make(map[string]model.AttributeOption, len(current.Options))
+	for _, option := range current.Options {
+		existing[strings.ToLower(option.Value)] = option
+	}
+	for i := range attribute.Options {
+		key := strings.ToLower(attribute.Options[i].Value)
+		if option, ok := existing[key]; ok {
+			attribute.Options[i].ID = option.ID
+			attribute.Options[i].Value = option.Value
+			delete(existing, key)
+		}
+	}
+
+	removed := make([]uint64, 0, len(existing))
+	for _, option := range existing {
+		removed = append(removed, option.ID)
+	}
+	inUse, err := s.attributeRepo.CountValuesUsingOptions(removed)
+	if err != nil {
+		return err
+	}
+	if inUse > 0 {
+		return ErrAttributeOptionInUse
+	}
+
+	options := attribute.Options
+	attribute.Options = nil
+	if err := s.attributeRepo.Update(attribute, options); err != nil {
+		return err
+	}
+	attribute.Options = options
+	return nil
+}
+
+func (s *attributeService) DeleteAttribute(id uint64) error {
+	if _, err := s.GetAttribute(id); err != nil {
+		return err
+	}
+	return s.attributeRepo.Delete(id)
+}
+
+// GetCategoryAttributes returns the category's own attributes, then inherited ones nearest first
+func (s *attributeService) GetCategoryAttributes(categoryID uint64) ([]model.CategoryAttribute, error) {
+	category, err := s.categoryRepo.GetByID(categoryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	return s.effectiveAttributes([]model.Category{*category})
+}
+
+func (s *attributeService) SetCategoryAttributes(categoryID uint64, links []model.CategoryAttribute) ([]model.CategoryAttribute, error) {
+	if _, err := s.categoryRepo.GetByID(categoryID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+
+	seen := make(map[uint64]bool, len(links))
+	for i := range links {
+		if seen[links[i].AttributeID] {
+			return nil, ErrDuplicateAttribute
+		}
+		seen[links[i].AttributeID] = true
+		if _, err := s.GetAttribute(links[i].AttributeID); err != nil {
+			return nil, err
+		}
+		links[i].Position = i
+	}
+
+	if err := s.attributeRepo.SetCategoryAttributes(categoryID, links); err != nil {
+		return nil, err
+	}
+	return s.GetCategoryAttributes(categoryID)
+}
+
+// SetProductAttributes takes values keyed by attribute code; a null value leaves the attribute unset
+func (s *attributeService) SetProductAttributes(productID uint64, values map[string]any) (*model.Product, error) {
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	categories := make([]model.Category, 0, len(product.Categories))
+	for _, link := range product.Categories {
+		category, err := s.categoryRepo.GetByID(link.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, *category)
+	}
+	links, err := s.effectiveAttributes(categories)
+	if err != nil {
+		return nil, err
+	}
+
+	byCode := make(map[string]model.CategoryAttribute, len(links))
+	for _, link := range links {
+		byCode[link.Attribute.Code] = link
+	}
+	for code := range values {
+		if _, ok := byCode[code]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownAttribute, code)
+		}
+	}
+
+	stored := make([]model.ProductAttributeValue, 0, len(values))
+	for _, link := range links {
+		raw, ok := values[link.Attribute.Code]
+		if !ok || raw == nil {
+			if link.IsRequired {
+				return nil, fmt.Errorf("%w: %s", ErrRequiredAttributeMissing, link.Attribute.Code)
+			}
+			continue
+		}
+		value, err := attributeValue(link.Attribute, raw)
+		if err != nil {
+			return nil, err
+		}
+		stored = append(stored, value)
+	}
+
+	if err := s.attributeRepo.SetProductValues(productID, stored); err != nil {
+		return nil, err
+	}
+	return s.productRepo.FindByID(productID)
+}
+
+// An attribute reached through several categories is required if any assignment requires it
+func (s *attributeService) effectiveAttributes(categories []model.Category) ([]model.CategoryAttribute, error) {
+	var categoryIDs []uint64
+	rank := make(map[uint64]int)
+	for _, category := range categories {
+		ancestors, err := s.categoryRepo.GetAncestors(&category)
+		if err != nil {
+			return nil, err
+		}
+		chain := append([]model.Category{category}, reverseCategories(ancestors)...)
+		for distance, c := range chain {
+			r, seen := rank[c.ID]
+			if !seen {
+				categoryIDs = append(categoryIDs, c.ID)
+			}
+			if !seen || distance < r {
+				rank[c.ID] = distance
+			}
+		}
+	}
+
+	links, err := s.attributeRepo.GetCategoryAttributes(categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(links, func(i, j int) bool {
+		return rank[links[i].CategoryID] < rank[links[j].CategoryID]
+	})
+
+	result := make([]model.CategoryAttribute, 0, len(links))
+	index := make(map[uint64]int, len(links))
+	for _, link := range links {
+		if i, ok := index[link.AttributeID]; ok {
+			result[i].IsRequired = result[i].IsRequired || link.IsRequired
+			continue
+		}
+		index[link.AttributeID] = len(result)
+		result = append(result, link)
+	}
+	return result, nil
+}
+
+func attributeValue(attribute *model.Attribute, raw any) (model.ProductAttributeValue, error) {
+	value := model.ProductAttributeValue{AttributeID: attribute.ID}
+	invalid := fmt.Errorf("%w: %s", ErrInvalidAttributeValue, attribute.Code)
+
+	switch attribute.Type {
+	case model.AttributeTypeText:
+		text, ok := raw.(string)
+		text = strings.TrimSpace(text)
+		if !ok || text == "" || utf8.RuneCountInString(text) > maxAttributeTextLength {
+			return value, invalid
+		}
+		value.TextValue = &text
+	case model.AttributeTypeNumber:
+		number, ok := raw.(float64)
+		if !ok {
+			return value, invalid
+		}
+		value.NumberValue = &number
+	case model.AttributeTypeBoolean:
+		flag, ok := raw.(bool)
+		if !ok {
+			return value, invalid
+		}
+		value.BoolValue = &flag
+	case model.AttributeTypeEnum:
+		text, ok := raw.(string)
+		if !ok {
+			return value, invalid
+		}
+		for i := range attribute.Options {
+			if strings.EqualFold(attribute.Options[i].Value, strings.TrimSpace(text)) {
+				value.OptionID = &attribute.Options[i].ID
+				return value, nil
+			}
+		}
+		return value, invalid
+	default:
+		return value, invalid
+	}
+	return value, nil
+}
+
+func normalizeAttribute(attribute *model.Attribute) error {
+	attribute.Name = strings.TrimSpace(attribute.Name)
+	attribute.Unit = strings.TrimSpace(attribute.Unit)
+	if attribute.Type != model.AttributeTypeNumber {
+		attribute.Unit = ""
+	}
+	if attribute.Type != model.AttributeTypeEnum {
+		attribute.Options = nil
+		return nil
+	}
+	if len(attribute.Options) == 0 {
+		return ErrEnumOptionsRequired
+	}
+
+	seen := make(map[string]bool, len(attribute.Options))
+	for i := range attribute.Options {
+		option := &attribute.Options[i]
+		option.Value = strings.TrimSpace(option.Value)
+		key := strings.ToLower(option.Value)
+		if key == "" {
+			return ErrEnumOptionsRequired
+		}
+		if seen[key] {
+			return ErrDuplicateAttribute
+		}
+		seen[key] = true
+		option.Position = i
+	}
+	return nil
+}
+
+func reverseCategories(categories []model.Category) []model.Category {
+	reversed := make([]model.Category, len(categories))
+	for i, category := range categories {
+		reversed[len(categories)-1-i] = category
+	}
+	return reversed
+}
@@ -1 +1,531 @@
 package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"github.com/Durgarao310/zneha-backend/pkg/hash"
+	"github.com/Durgarao310/zneha-backend/pkg/jwt"
+	"github.com/Durgarao310/zneha-backend/pkg/mailer"
+	"github.com/Durgarao310/zneha-backend/pkg/sms"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrEmailTaken           = errors.New("email is already registered")
+	ErrInvalidCredentials   = errors.New("invalid email or password")
+	ErrUserInactive         = errors.New("user account is disabled")
+	ErrInvalidRefreshToken  = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused   = errors.New("refresh token reuse detected, session revoked")
+	ErrSessionRevoked       = errors.New("session has been revoked")
+	ErrEmailNotVerified     = errors.New("email address has not been verified")
+	ErrEmailAlreadyVerified = errors.New("email address is already verified")
+	ErrInvalidUserToken     = errors.New("invalid or expired token")
+	ErrNoEmailAddress       = errors.New("account has no email address")
+)
+
+const (
+	refreshTokenBytes = 32              // randomness in an opaque refresh token
+	userTokenBytes    = 32              // randomness in emailed reset and verification tokens
+	mfaTokenTTL       = 5 * time.Minute // time allowed to enter the second factor
+)
+
+type AuthOptions struct {
+	RefreshTTL               time.Duration
+	PasswordResetTTL         time.Duration
+	EmailVerificationTTL     time.Duration
+	RequireEmailVerification bool
+	FrontendURL              string        // base URL for links in emails
+	OTPTTL                   time.Duration // lifetime of SMS login codes
+	OTPMaxAttempts           int           // wrong entries before a code is burned
+	OTPResendInterval        time.Duration // minimum time between codes for one number
+	OTPRequestsPerHour       int           // codes one number can request per hour
+}
+
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+// When MFARequired is set only the MFA token is filled in
+type AuthResult struct {
+	User             *model.User
+	AccessToken      string
+	ExpiresAt        time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+	SessionID        string
+	MFARequired      bool
+	MFAToken         string
+	MFAExpiresAt     time.Time
+}
+
+type AuthService interface {
+	Register(email, password, name string) (*model.User, error)
+	Login(email, password string, client ClientInfo) (*AuthResult, error)
+	VerifyMFA(mfaToken, code string, client ClientInfo) (*AuthResult, error)
+	RequestPhoneOTP(phone string, client ClientInfo) (time.Time, error)
+	VerifyPhoneOTP(phone, code string, client ClientInfo) (*AuthResult, error)
+	Refresh(refreshToken string, client ClientInfo) (*AuthResult, error)
+	Logout(refreshToken string) error
+	LogoutAll(userID uint64) error
+	RevokeSession(sessionID string) error
+	RequestPasswordReset(email string) error
+	ResetPassword(token, newPassword string) error
+	SendVerificationEmail(userID uint64) error
+	VerifyEmail(token string) error
+	GetUserByID(id uint64) (*model.User, error)
+	ValidateAccessToken(token string) (*jwt.Claims, error)
+	IsSessionRevoked(sessionID string) (bool, error)
+}
+
+type authService struct {
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	userTokenRepo    repository.UserTokenRepository
+	phoneOTPRepo     repository.PhoneOTPRepository
+	twoFactorService TwoFactorService
+	loginThrottle    LoginThrottleService
+	jwtManager       *jwt.Manager
+	mailer           mailer.Mailer
+	smsSender        sms.SMSSender
+	options          AuthOptions
+}
+
+func NewAuthService(
+	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	userTokenRepo repository.UserTokenRepository,
+	phoneOTPRepo repository.PhoneOTPRepository,
+	twoFactorService TwoFactorService,
+	loginThrottle LoginThrottleService,
+	jwtManager *jwt.Manager,
+	mailer mailer.Mailer,
+	smsSender sms.SMSSender,
+	options AuthOptions,
+) AuthService {
+	return &authService{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		userTokenRepo:    userTokenRepo,
+		phoneOTPRepo:     phoneOTPRepo,
+		twoFactorService: twoFactorService,
+		loginThrottle:    loginThrottle,
+		jwtManager:       jwtManager,
+		mailer:           mailer,
+		smsSender:        smsSender,
+		options:          options,
+	}
+}
+
+func (s *authService) Register(email, password, name string) (*model.User, error) {
+	email = normalizeEmail(email)
+
+	exists, err := s.userRepo.ExistsByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrEmailTaken
+	}
+
+	passwordHash, err := hash.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &model.User{
+		Email:        email,
+		PasswordHash: passwordHash,
+		Name:         strings.TrimSpace(name),
+		IsActive:     true,
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		if repository.IsUniqueViolation(err, "idx_user_email_address") {
+			return nil, ErrEmailTaken
+		}
+		return nil, err
+	}
+
+	// A failed delivery does not undo the registration; the user can ask for a new email
+	_ = s.sendVerificationEmail(user)
+
+	return user, nil
+}
+
+func (s *authService) Login(email, password string, client ClientInfo) (*AuthResult, error) {
+	email = normalizeEmail(email)
+	if err := s.loginThrottle.Check(email, client.IPAddress); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Unknown emails are throttled too, so lockouts do not reveal which accounts exist
+			return nil, s.loginFailed(email, client, nil, ErrInvalidCredentials)
+		}
+		return nil, err
+	}
+
+	if !hash.CheckPassword(user.PasswordHash, password) {
+		return nil, s.loginFailed(email, client, &user.ID, ErrInvalidCredentials)
+	}
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+	if s.options.RequireEmailVerification && user.EmailVerifiedAt == nil {
+		return nil, ErrEmailNotVerified
+	}
+
+	// Users with two-factor authentication get a short-lived challenge token instead
+	if user.TOTPEnabledAt != nil {
+		return s.mfaChallenge(user)
+	}
+
+	return s.completeLogin(email, user, client, false)
+}
+
+func (s *authService) VerifyMFA(mfaToken, code string, client ClientInfo) (*AuthResult, error) {
+	claims, err := s.jwtManager.ParseMFAToken(mfaToken)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := s.loginThrottle.Check(claims.Email, client.IPAddress); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(claims.UserID)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+
+	ok, err := s.twoFactorService.VerifyCode(user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, s.loginFailed(claims.Email, client, &user.ID, ErrInvalidTwoFactorCode)
+	}
+
+	return s.completeLogin(claims.Email, user, client, true)
+}
+
+// Refresh rotates the token pair. A rotated token presented again revokes its family.
+func (s *authService) Refresh(refreshToken string, client ClientInfo) (*AuthResult, error) {
+	current, err := s.refreshTokenRepo.GetByHash(hash.SHA256(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if current.RevokedAt != nil || time.Now().After(current.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if current.RotatedAt != nil {
+		if err := s.refreshTokenRepo.RevokeFamily(current.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	user, err := s.userRepo.GetByID(current.UserID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+
+	next, plain, err := s.newRefreshToken(user.ID, current.FamilyID, current.MFAVerified, client)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refreshTokenRepo.Rotate(current, next); err != nil {
+		if errors.Is(err, repository.ErrTokenAlreadyRotated) {
+			if err := s.refreshTokenRepo.RevokeFamily(current.FamilyID); err != nil {
+				return nil, err
+			}
+			return nil, ErrRefreshTokenReused
+		}
+		return nil, err
+	}
+
+	return s.buildResult(user, next, plain)
+}
+
+func (s *authService) Logout(refreshToken string) error {
+	token, err := s.refreshTokenRepo.GetByHash(hash.SHA256(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidRefreshToken
+		}
+		return err
+	}
+	return s.refreshTokenRepo.RevokeFamily(token.FamilyID)
+}
+
+func (s *authService) LogoutAll(userID uint64) error {
+	return s.refreshTokenRepo.RevokeAllForUser(userID)
+}
+
+func (s *authService) RevokeSession(sessionID string) error {
+	return s.refreshTokenRepo.RevokeFamily(sessionID)
+}
+
+// Unknown addresses are ignored so the endpoint does not reveal registered emails
+func (s *authService) RequestPasswordReset(email string) error {
+	user, err := s.userRepo.GetByEmail(normalizeEmail(email))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !user.IsActive {
+		return nil
+	}
+
+	token, err := s.issueUserToken(user.ID, model.TokenPurposePasswordReset, s.options.PasswordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to reset your password. It expires in %d minutes.\n\n%s\n\nIf you did not request a reset, you can ignore this email.\n",
+			displayName(user), int(s.options.PasswordResetTTL.Minutes()), s.link("/reset-password", token)),
+	})
+}
+
+func (s *authService) ResetPassword(token, newPassword string) error {
+	userToken, err := s.consumeUserToken(model.TokenPurposePasswordReset, token)
+	if err != nil {
+		return err
+	}
+
+	passwordHash, err := hash.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := s.userRepo.UpdatePassword(userToken.UserID, passwordHash); err != nil {
+		return err
+	}
+	return s.refreshTokenRepo.RevokeAllForUser(userToken.UserID)
+}
+
+func (s *authService) SendVerificationEmail(userID uint64) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if user.Email == "" {
+		return ErrNoEmailAddress
+	}
+	if user.EmailVerifiedAt != nil {
+		return ErrEmailAlreadyVerified
+	}
+	return s.sendVerificationEmail(user)
+}
+
+func (s *authService) VerifyEmail(token string) error {
+	userToken, err := s.consumeUserToken(model.TokenPurposeEmailVerification, token)
+	if err != nil {
+		return err
+	}
+	return s.userRepo.MarkEmailVerified(userToken.UserID, time.Now())
+}
+
+func (s *authService) GetUserByID(id uint64) (*model.User, error) {
+	return s.userRepo.GetByID(id)
+}
+
+func (s *authService) ValidateAccessToken(token string) (*jwt.Claims, error) {
+	return s.jwtManager.ParseAccessToken(token)
+}
+
+func (s *authService) IsSessionRevoked(sessionID string) (bool, error) {
+	if sessionID == "" {
+		return false, nil
+	}
+	return s.refreshTokenRepo.IsFamilyRevoked(sessionID)
+}
+
+func (s *authService) mfaChallenge(user *model.User) (*AuthResult, error) {
+	mfaToken, expiresAt, err := s.jwtManager.GenerateMFAToken(user.ID, loginKey(user), mfaTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthResult{
+		User:         user,
+		MFARequired:  true,
+		MFAToken:     mfaToken,
+		MFAExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *authService) loginFailed(key string, client ClientInfo, userID *uint64, cause error) error {
+	if err := s.loginThrottle.RecordFailure(key, client.IPAddress, userID); err != nil {
+		return err
+	}
+	return cause
+}
+
+func (s *authService) completeLogin(key string, user *model.User, client ClientInfo, mfaVerified bool) (*AuthResult, error) {
+	result, err := s.startSession(user, client, mfaVerified)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.loginThrottle.RecordSuccess(key); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *authService) startSession(user *model.User, client ClientInfo, mfaVerified bool) (*AuthResult, error) {
+	token, plain, err := s.newRefreshToken(user.ID, uuid.New().String(), mfaVerified, client)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refreshTokenRepo.Create(token); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.userRepo.UpdateLastLogin(user.ID, now); err != nil {
+		return nil, err
+	}
+	user.LastLoginAt = &now
+
+	return s.buildResult(user, token, plain)
+}
+
+func (s *authService) newRefreshToken(userID uint64, familyID string, mfaVerified bool, client ClientInfo) (*model.RefreshToken, string, error) {
+	plain, err := hash.GenerateToken(refreshTokenBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	return &model.RefreshToken{
+		UserID:      userID,
+		FamilyID:    familyID,
+		TokenHash:   hash.SHA256(plain),
+		ExpiresAt:   time.Now().Add(s.options.RefreshTTL),
+		MFAVerified: mfaVerified,
+		IPAddress:   client.IPAddress,
+		UserAgent:   truncate(client.UserAgent, 500),
+	}, plain, nil
+}
+
+func (s *authService) buildResult(user *model.User, refresh *model.RefreshToken, plain string) (*AuthResult, error) {
+	accessToken, expiresAt, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, refresh.FamilyID, refresh.MFAVerified)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthResult{
+		User:             user,
+		AccessToken:      accessToken,
+		ExpiresAt:        expiresAt,
+		RefreshToken:     plain,
+		RefreshExpiresAt: refresh.ExpiresAt,
+		SessionID:        refresh.FamilyID,
+	}, nil
+}
+
+func (s *authService) sendVerificationEmail(user *model.User) error {
+	token, err := s.issueUserToken(user.ID, model.TokenPurposeEmailVerification, s.options.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address using the link below.\n\n%s\n",
+			displayName(user), s.link("/verify-email", token)),
+	})
+}
+
+// issueUserToken supersedes outstanding tokens of the purpose and stores a new hashed one
+func (s *authService) issueUserToken(userID uint64, purpose string, ttl time.Duration) (string, error) {
+	if err := s.userTokenRepo.InvalidateForUser(userID, purpose); err != nil {
+		return "", err
+	}
+
+	plain, err := hash.GenerateToken(userTokenBytes)
+	if err != nil {
+		return "", err
+	}
+	token := &model.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hash.SHA256(plain),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.userTokenRepo.Create(token); err != nil {
+		return "", err
+	}
+	return plain, nil
+}
+
+func (s *authService) consumeUserToken(purpose, plain string) (*model.UserToken, error) {
+	token, err := s.userTokenRepo.GetByHash(purpose, hash.SHA256(plain))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidUserToken
+		}
+		return nil, err
+	}
+	if token.UsedAt != nil || time.Now().After(token.ExpiresAt) {
+		return nil, ErrInvalidUserToken
+	}
+	if err := s.userTokenRepo.MarkUsed(token.ID); err != nil {
+		if errors.Is(err, repository.ErrTokenAlreadyUsed) {
+			return nil, ErrInvalidUserToken
+		}
+		return nil, err
+	}
+	return token, nil
+}
+
+func (s *authService) link(path, token string) string {
+	return strings.TrimRight(s.options.FrontendURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+func displayName(user *model.User) string {
+	if user.Name != "" {
+		return user.Name
+	}
+	return loginKey(user)
+}
+
+// loginKey returns the identifier the user logs in with, used as the throttle key
+func loginKey(user *model.User) string {
+	if user.Email == "" && user.Phone != nil {
+		return *user.Phone
+	}
+	return user.Email
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func truncate(s string, max int) string {
+	if len(s) > max {
+		return s[:max]
+	}
+	return s
+}
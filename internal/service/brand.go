@@ -0,0 +1,167 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBrandNotFound     = errors.New("brand not found")
+	ErrLogoMediaNotFound = errors.New("logo media does not exist")
+)
+
+type BrandService interface {
+	CreateBrand(brand *model.Brand) error
+	GetBrand(id uint64) (*model.Brand, error)
+	GetBrandBySlug(slug string) (brand *model.Brand, moved bool, err error)
+	ListBrands(page, limit int) ([]model.Brand, int64, error)
+	UpdateBrand(brand *model.Brand) error
+	DeleteBrand(id uint64) error
+	GetBrandProducts(id uint64, page, limit int) ([]model.Product, int64, error)
+}
+
+type brandService struct {
+	brandRepo   repository.BrandRepository
+	productRepo repository.ProductRepository
+	mediaRepo   repository.MediaRepository
+	slugRepo    repository.SlugRedirectRepository
+}
+
+func NewBrandService(brandRepo repository.BrandRepository, productRepo repository.ProductRepository, mediaRepo repository.MediaRepository, slugRepo repository.SlugRedirectRepository) BrandService {
+	return &brandService{
+		brandRepo:   brandRepo,
+		productRepo: productRepo,
+		mediaRepo:   mediaRepo,
+		slugRepo:    slugRepo,
+	}
+}
+
+func (s *brandService) CreateBrand(brand *model.Brand) error {
+	if err := s.checkLogo(brand.LogoMediaID); err != nil {
+		return err
+	}
+
+	var err error
+	brand.Slug, err = resolveSlug(s.slugRepo, model.SlugEntityBrand, 0, brand.Slug, brand.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.brandRepo.Create(brand); err != nil {
+		return slugConflict(model.SlugEntityBrand, err)
+	}
+	return s.reload(brand)
+}
+
+func (s *brandService) GetBrand(id uint64) (*model.Brand, error) {
+	brand, err := s.brandRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBrandNotFound
+		}
+		return nil, err
+	}
+	return brand, nil
+}
+
+// GetBrandBySlug also resolves earlier slugs, reporting moved for them
+func (s *brandService) GetBrandBySlug(slug string) (*model.Brand, bool, error) {
+	slug = strings.ToLower(slug)
+	brand, err := s.brandRepo.GetBySlug(slug)
+	if err == nil {
+		return brand, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, err
+	}
+
+	redirect, err := s.slugRepo.Find(model.SlugEntityBrand, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, ErrBrandNotFound
+		}
+		return nil, false, err
+	}
+	brand, err = s.GetBrand(redirect.EntityID)
+	if err != nil {
+		return nil, false, err
+	}
+	return brand, true, nil
+}
+
+func (s *brandService) ListBrands(page, limit int) ([]model.Brand, int64, error) {
+	return s.brandRepo.GetAllWithPagination(page, limit)
+}
+
+// UpdateBrand keeps the slug when it is empty
+func (s *brandService) UpdateBrand(brand *model.Brand) error {
+	existing, err := s.GetBrand(brand.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkLogo(brand.LogoMediaID); err != nil {
+		return err
+	}
+
+	switch {
+	case brand.Slug != "":
+		brand.Slug, err = resolveSlug(s.slugRepo, model.SlugEntityBrand, brand.ID, brand.Slug, brand.Name)
+	case existing.Slug != "":
+		brand.Slug = existing.Slug
+	default:
+		brand.Slug, err = resolveSlug(s.slugRepo, model.SlugEntityBrand, brand.ID, "", brand.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.brandRepo.Update(brand); err != nil {
+		return slugConflict(model.SlugEntityBrand, err)
+	}
+	return s.reload(brand)
+}
+
+func (s *brandService) DeleteBrand(id uint64) error {
+	if _, err := s.GetBrand(id); err != nil {
+		return err
+	}
+	return s.brandRepo.Delete(id)
+}
+
+func (s *brandService) GetBrandProducts(id uint64, page, limit int) ([]model.Product, int64, error) {
+	if _, err := s.GetBrand(id); err != nil {
+		return nil, 0, err
+	}
+	params := pagination.PaginationParams{Page: page, Limit: limit, WithTotal: true}
+	products, info, err := s.productRepo.FindWithPagination(repository.ProductFilter{BrandIDs: []uint64{id}}, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	return products, *info.Total, nil
+}
+
+func (s *brandService) checkLogo(mediaID *uint64) error {
+	if mediaID == nil {
+		return nil
+	}
+	if _, err := s.mediaRepo.GetByID(*mediaID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrLogoMediaNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *brandService) reload(brand *model.Brand) error {
+	saved, err := s.brandRepo.GetByID(brand.ID)
+	if err != nil {
+		return err
+	}
+	*brand = *saved
+	return nil
+}
@@ -2,14 +2,24 @@ package service
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/Durgarao310/zneha-backend/internal/model"
 	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCategoryNotFound       = errors.New("category not found")
+	ErrParentCategoryNotFound = errors.New("parent category does not exist")
+	ErrCategoryCycle          = errors.New("category cannot be moved into its own subtree")
+	ErrInvalidCategoryOrder   = errors.New("ids must list every sibling category exactly once")
 )
 
 type CategoryService interface {
 	CreateCategory(category *model.Category) error
 	GetCategoryByID(id uint64) (*model.Category, error)
+	GetCategoryBySlug(slug string) (category *model.Category, moved bool, err error)
 	GetAllCategories() ([]model.Category, error)
 	GetMainCategories() ([]model.Category, error)
 	GetSubCategories(parentID uint64) ([]model.Category, error)
@@ -17,15 +27,22 @@ type CategoryService interface {
 	DeleteCategory(id uint64) error
 	GetAllCategoriesWithPagination(page, limit int) ([]model.Category, int64, error)
 	GetSubCategoriesWithPagination(parentID uint64, page, limit int) ([]model.Category, int64, error)
+	GetAncestors(id uint64) ([]model.Category, error)
+	GetDescendants(id uint64, maxDepth int) ([]model.Category, error)
+	MoveCategory(id uint64, newParentID *uint64) (*model.Category, error)
+	GetTree(rootID *uint64, maxDepth int) ([]repository.CategoryWithProductCount, error)
+	ReorderCategories(parentID *uint64, ids []uint64) error
 }
 
 type categoryService struct {
 	categoryRepo repository.CategoryRepository
+	slugRepo     repository.SlugRedirectRepository
 }
 
-func NewCategoryService(categoryRepo repository.CategoryRepository) CategoryService {
+func NewCategoryService(categoryRepo repository.CategoryRepository, slugRepo repository.SlugRedirectRepository) CategoryService {
 	return &categoryService{
 		categoryRepo: categoryRepo,
+		slugRepo:     slugRepo,
 	}
 }
 
@@ -38,20 +55,48 @@ func (s *categoryService) CreateCategory(category *model.Category) error {
 	if category.ParentID != nil {
 		_, err := s.categoryRepo.GetByID(*category.ParentID)
 		if err != nil {
-			return errors.New("parent category does not exist")
+			return ErrParentCategoryNotFound
 		}
-		category.Depth = 1 // Set as subcategory
-	} else {
-		category.Depth = 0 // Set as main category
 	}
 
-	return s.categoryRepo.Create(category)
+	slug, err := resolveSlug(s.slugRepo, model.SlugEntityCategory, 0, category.Slug, category.Name)
+	if err != nil {
+		return err
+	}
+	category.Slug = slug
+
+	return slugConflict(model.SlugEntityCategory, s.categoryRepo.Create(category))
 }
 
 func (s *categoryService) GetCategoryByID(id uint64) (*model.Category, error) {
 	return s.categoryRepo.GetByID(id)
 }
 
+// GetCategoryBySlug also resolves earlier slugs, reporting moved for them
+func (s *categoryService) GetCategoryBySlug(slug string) (*model.Category, bool, error) {
+	slug = strings.ToLower(slug)
+	category, err := s.categoryRepo.GetBySlug(slug)
+	if err == nil {
+		return category, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, err
+	}
+
+	redirect, err := s.slugRepo.Find(model.SlugEntityCategory, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, ErrCategoryNotFound
+		}
+		return nil, false, err
+	}
+	category, err = s.categoryRepo.GetByID(redirect.EntityID)
+	if err != nil {
+		return nil, false, ErrCategoryNotFound
+	}
+	return category, true, nil
+}
+
 func (s *categoryService) GetAllCategories() ([]model.Category, error) {
 	return s.categoryRepo.GetAll()
 }
@@ -64,18 +109,46 @@ func (s *categoryService) GetSubCategories(parentID uint64) ([]model.Category, e
 	return s.categoryRepo.GetByParentID(&parentID)
 }
 
+// UpdateCategory keeps the slug when it is empty and moves the subtree when ParentID changes
 func (s *categoryService) UpdateCategory(category *model.Category) error {
 	if category.Name == "" {
 		return errors.New("category name is required")
 	}
 
 	// Check if category exists
-	_, err := s.categoryRepo.GetByID(category.ID)
+	existing, err := s.categoryRepo.GetByID(category.ID)
 	if err != nil {
-		return errors.New("category not found")
+		return ErrCategoryNotFound
 	}
 
-	return s.categoryRepo.Update(category)
+	switch {
+	case category.Slug != "":
+		category.Slug, err = resolveSlug(s.slugRepo, model.SlugEntityCategory, category.ID, category.Slug, category.Name)
+	case existing.Slug != "":
+		category.Slug = existing.Slug
+	default:
+		category.Slug, err = resolveSlug(s.slugRepo, model.SlugEntityCategory, category.ID, "", category.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !repository.SameParentID(existing.ParentID, category.ParentID) {
+		if _, err := s.MoveCategory(category.ID, category.ParentID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.categoryRepo.Update(category); err != nil {
+		return slugConflict(model.SlugEntityCategory, err)
+	}
+
+	updated, err := s.categoryRepo.GetByID(category.ID)
+	if err != nil {
+		return err
+	}
+	*category = *updated
+	return nil
 }
 
 func (s *categoryService) DeleteCategory(id uint64) error {
@@ -95,3 +168,66 @@ func (s *categoryService) GetAllCategoriesWithPagination(page, limit int) ([]mod
 func (s *categoryService) GetSubCategoriesWithPagination(parentID uint64, page, limit int) ([]model.Category, int64, error) {
 	return s.categoryRepo.GetByParentIDWithPagination(&parentID, page, limit)
 }
+
+func (s *categoryService) GetAncestors(id uint64) ([]model.Category, error) {
+	category, err := s.categoryRepo.GetByID(id)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+	return s.categoryRepo.GetAncestors(category)
+}
+
+func (s *categoryService) GetDescendants(id uint64, maxDepth int) ([]model.Category, error) {
+	category, err := s.categoryRepo.GetByID(id)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+	return s.categoryRepo.GetDescendants(category, maxDepth)
+}
+
+func (s *categoryService) MoveCategory(id uint64, newParentID *uint64) (*model.Category, error) {
+	if newParentID != nil && *newParentID == id {
+		return nil, ErrCategoryCycle
+	}
+
+	category, err := s.categoryRepo.MoveSubtree(id, newParentID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCategoryCycle):
+			return nil, ErrCategoryCycle
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if _, getErr := s.categoryRepo.GetByID(id); getErr != nil {
+				return nil, ErrCategoryNotFound
+			}
+			return nil, ErrParentCategoryNotFound
+		}
+		return nil, err
+	}
+	return category, nil
+}
+
+func (s *categoryService) GetTree(rootID *uint64, maxDepth int) ([]repository.CategoryWithProductCount, error) {
+	var root *model.Category
+	if rootID != nil {
+		category, err := s.categoryRepo.GetByID(*rootID)
+		if err != nil {
+			return nil, ErrCategoryNotFound
+		}
+		root = category
+	}
+	return s.categoryRepo.GetTree(root, maxDepth)
+}
+
+func (s *categoryService) ReorderCategories(parentID *uint64, ids []uint64) error {
+	if parentID != nil {
+		if _, err := s.categoryRepo.GetByID(*parentID); err != nil {
+			return ErrParentCategoryNotFound
+		}
+	}
+
+	err := s.categoryRepo.ReorderSiblings(parentID, ids)
+	if errors.Is(err, repository.ErrSiblingSetMismatch) {
+		return ErrInvalidCategoryOrder
+	}
+	return err
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAccountLocked   = errors.New("account is temporarily locked after too many failed login attempts")
+	ErrTooManyAttempts = errors.New("too many login attempts, try again later")
+)
+
+type ThrottleError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *ThrottleError) Error() string { return e.Err.Error() }
+
+func (e *ThrottleError) Unwrap() error { return e.Err }
+
+// LockoutPolicy holds the brute-force thresholds. A zero max disables that scope.
+type LockoutPolicy struct {
+	MaxFailures     int           // failures per account before a lockout
+	IPMaxFailures   int           // failures per client IP before a lockout
+	LockoutDuration time.Duration // how long a lockout lasts, also the window failures are counted in
+	BackoffBase     time.Duration // delay after the first failure, doubled on every further failure
+	BackoffMax      time.Duration // upper bound of the backoff delay
+}
+
+type LoginThrottleService interface {
+	Check(key, ipAddress string) error
+	RecordFailure(key, ipAddress string, userID *uint64) error
+	RecordSuccess(key string) error
+	UnlockUser(userID, adminID uint64) error
+	ListLockouts(activeOnly bool, page, limit int) ([]model.LockoutEvent, int64, error)
+}
+
+type loginThrottleService struct {
+	repo     repository.LoginThrottleRepository
+	userRepo repository.UserRepository
+	policy   LockoutPolicy
+}
+
+func NewLoginThrottleService(repo repository.LoginThrottleRepository, userRepo repository.UserRepository, policy LockoutPolicy) LoginThrottleService {
+	return &loginThrottleService{
+		repo:     repo,
+		userRepo: userRepo,
+		policy:   policy,
+	}
+}
+
+func (s *loginThrottleService) Check(key, ipAddress string) error {
+	if err := s.check(model.ThrottleScopeAccount, key, ErrAccountLocked); err != nil {
+		return err
+	}
+	if ipAddress == "" {
+		return nil
+	}
+	return s.check(model.ThrottleScopeIP, ipAddress, ErrTooManyAttempts)
+}
+
+func (s *loginThrottleService) RecordFailure(key, ipAddress string, userID *uint64) error {
+	if err := s.recordFailure(model.ThrottleScopeAccount, key, ipAddress, userID, s.policy.MaxFailures); err != nil {
+		return err
+	}
+	if ipAddress == "" {
+		return nil
+	}
+	return s.recordFailure(model.ThrottleScopeIP, ipAddress, ipAddress, nil, s.policy.IPMaxFailures)
+}
+
+// RecordSuccess clears only the account counter, so one login cannot reset guessing from its IP
+func (s *loginThrottleService) RecordSuccess(key string) error {
+	return s.repo.Reset(model.ThrottleScopeAccount, key)
+}
+
+func (s *loginThrottleService) UnlockUser(userID, adminID uint64) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	for _, key := range loginKeys(user) {
+		if err := s.repo.Reset(model.ThrottleScopeAccount, key); err != nil {
+			return err
+		}
+		if err := s.repo.MarkEventsUnlocked(model.ThrottleScopeAccount, key, adminID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *loginThrottleService) ListLockouts(activeOnly bool, page, limit int) ([]model.LockoutEvent, int64, error) {
+	return s.repo.ListEvents(activeOnly, page, limit)
+}
+
+func (s *loginThrottleService) check(scope, key string, lockedErr error) error {
+	throttle, err := s.repo.Get(scope, key)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	now := time.Now()
+	if throttle.LockedUntil != nil && throttle.LockedUntil.After(now) {
+		return &ThrottleError{Err: lockedErr, RetryAfter: throttle.LockedUntil.Sub(now)}
+	}
+	if throttle.BlockedUntil != nil && throttle.BlockedUntil.After(now) {
+		return &ThrottleError{Err: ErrTooManyAttempts, RetryAfter: throttle.BlockedUntil.Sub(now)}
+	}
+	return nil
+}
+
+func (s *loginThrottleService) recordFailure(scope, key, ipAddress string, userID *uint64, maxFailures int) error {
+	now := time.Now()
+	throttle, err := s.repo.IncrementFailures(scope, key, now.Add(-s.policy.LockoutDuration))
+	if err != nil {
+		return err
+	}
+
+	if maxFailures > 0 && throttle.Failures >= maxFailures {
+		lockedUntil := now.Add(s.policy.LockoutDuration)
+		if err := s.repo.SetBlocks(throttle.ID, nil, &lockedUntil); err != nil {
+			return err
+		}
+		return s.repo.CreateEvent(&model.LockoutEvent{
+			Scope:       scope,
+			Key:         key,
+			UserID:      userID,
+			IPAddress:   ipAddress,
+			Failures:    throttle.Failures,
+			LockedUntil: lockedUntil,
+		})
+	}
+
+	blockedUntil := now.Add(s.backoff(throttle.Failures))
+	return s.repo.SetBlocks(throttle.ID, &blockedUntil, nil)
+}
+
+func (s *loginThrottleService) backoff(failures int) time.Duration {
+	delay := s.policy.BackoffBase
+	for i := 1; i < failures && delay < s.policy.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > s.policy.BackoffMax {
+		delay = s.policy.BackoffMax
+	}
+	return delay
+}
+
+func loginKeys(user *model.User) []string {
+	var keys []string
+	if user.Email != "" {
+		keys = append(keys, user.Email)
+	}
+	if user.Phone != nil {
+		keys = append(keys, *user.Phone)
+	}
+	return keys
+}
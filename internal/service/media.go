@@ -3,6 +3,7 @@ package service
 import (
 	"github.com/Durgarao310/zneha-backend/internal/model"
 	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
 )
 
 type MediaService struct {
@@ -27,8 +28,8 @@ func (s *MediaService) GetMediaByProductID(productID uint64) ([]model.Media, err
 	return s.mediaRepo.GetByProductID(productID)
 }
 
-func (s *MediaService) GetMediaByProductIDWithPagination(productID uint64, page, limit int) ([]model.Media, int64, error) {
-	return s.mediaRepo.GetByProductIDWithPagination(productID, page, limit)
+func (s *MediaService) GetMediaByProductIDWithPagination(productID uint64, spec pagination.QuerySpec, params pagination.PaginationParams) ([]model.Media, pagination.PageInfo, error) {
+	return s.mediaRepo.GetByProductIDWithPagination(productID, spec, params)
 }
 
 func (s *MediaService) GetMediaByVariantID(variantID uint64) ([]model.Media, error) {
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMetafieldDefinitionNotFound  = errors.New("metafield definition not found")
+	ErrMetafieldNotFound            = errors.New("metafield not found")
+	ErrMetafieldOwnerNotFound       = errors.New("resource not found")
+	ErrDuplicateMetafieldDefinition = errors.New("a metafield definition with this namespace and key already exists")
+	ErrInvalidMetafieldOwner        = errors.New("owner type must be product, variant, category or media")
+	ErrInvalidMetafieldKey          = errors.New("namespace and key must start with a letter and contain only lowercase letters, digits and underscores")
+	ErrInvalidMetafieldType         = errors.New("unknown metafield type")
+	ErrInvalidMetafieldVisibility   = errors.New("visibility must be public or admin")
+	ErrInvalidMetafieldValue        = errors.New("metafield value does not match the definition type")
+)
+
+// Value limits per text type
+const (
+	maxMetafieldLineLength = 255
+	maxMetafieldTextLength = 10000
+)
+
+// maxSafeInteger is the largest integer a JSON number keeps exactly
+const maxSafeInteger = 1<<53 - 1
+
+var validMetafieldKey = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type MetafieldService interface {
+	ListDefinitions(ownerType string) ([]model.MetafieldDefinition, error)
+	GetDefinition(id uint64) (*model.MetafieldDefinition, error)
+	CreateDefinition(definition *model.MetafieldDefinition) error
+	UpdateDefinition(definition *model.MetafieldDefinition) error
+	DeleteDefinition(id uint64) error
+	ListMetafields(ownerType string, ownerID uint64, includeAdmin bool) ([]model.Metafield, error)
+	SetMetafield(ownerType string, ownerID uint64, namespace, key string, value any) (*model.Metafield, error)
+	DeleteMetafield(ownerType string, ownerID uint64, namespace, key string) error
+}
+
+type metafieldService struct {
+	metafieldRepo repository.MetafieldRepository
+}
+
+func NewMetafieldService(metafieldRepo repository.MetafieldRepository) MetafieldService {
+	return &metafieldService{metafieldRepo: metafieldRepo}
+}
+
+func (s *metafieldService) ListDefinitions(ownerType string) ([]model.MetafieldDefinition, error) {
+	if ownerType != "" && !isMetafieldOwner(ownerType) {
+		return nil, ErrInvalidMetafieldOwner
+	}
+	return s.metafieldRepo.GetDefinitions(ownerType)
+}
+
+func (s *metafieldService) GetDefinition(id uint64) (*model.MetafieldDefinition, error) {
+	definition, err := s.metafieldRepo.GetDefinition(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMetafieldDefinitionNotFound
+		}
+		return nil, err
+	}
+	return definition, nil
+}
+
+func (s *metafieldService) CreateDefinition(definition *model.MetafieldDefinition) error {
+	if !isMetafieldOwner(definition.OwnerType) {
+		return ErrInvalidMetafieldOwner
+	}
+	definition.Namespace = strings.ToLower(strings.TrimSpace(definition.Namespace))
+	definition.Key = strings.ToLower(strings.TrimSpace(definition.Key))
+	if !validMetafieldKey.MatchString(definition.Namespace) || !validMetafieldKey.MatchString(definition.Key) {
+		return ErrInvalidMetafieldKey
+	}
+	if !isMetafieldType(definition.Type) {
+		return ErrInvalidMetafieldType
+	}
+	if definition.Visibility == "" {
+		definition.Visibility = model.MetafieldVisibilityAdmin
+	}
+	if !isMetafieldVisibility(definition.Visibility) {
+		return ErrInvalidMetafieldVisibility
+	}
+
+	_, err := s.metafieldRepo.FindDefinition(definition.OwnerType, definition.Namespace, definition.Key)
+	if err == nil {
+		return ErrDuplicateMetafieldDefinition
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return s.metafieldRepo.CreateDefinition(definition)
+}
+
+// UpdateDefinition keeps the owner type, namespace, key and type so stored values remain valid
+func (s *metafieldService) UpdateDefinition(definition *model.MetafieldDefinition) error {
+	current, err := s.GetDefinition(definition.ID)
+	if err != nil {
+		return err
+	}
+	if !isMetafieldVisibility(definition.Visibility) {
+		return ErrInvalidMetafieldVisibility
+	}
+	definition.OwnerType = current.OwnerType
+	definition.Namespace = current.Namespace
+	definition.Key = current.Key
+	definition.Type = current.Type
+	definition.CreatedAt = current.CreatedAt
+	return s.metafieldRepo.UpdateDefinition(definition)
+}
+
+func (s *metafieldService) DeleteDefinition(id uint64) error {
+	if _, err := s.GetDefinition(id); err != nil {
+		return err
+	}
+	return s.metafieldRepo.DeleteDefinition(id)
+}
+
+func (s *metafieldService) ListMetafields(ownerType string, ownerID uint64, includeAdmin bool) ([]model.Metafield, error) {
+	if err := s.checkOwner(ownerType, ownerID); err != nil {
+		return nil, err
+	}
+	return s.metafieldRepo.GetByOwner(ownerType, ownerID, !includeAdmin)
+}
+
+func (s *metafieldService) SetMetafield(ownerType string, ownerID uint64, namespace, key string, value any) (*model.Metafield, error) {
+	if err := s.checkOwner(ownerType, ownerID); err != nil {
+		return nil, err
+	}
+	definition, err := s.findDefinition(ownerType, namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	value, err = metafieldValue(definition.Type, value)
+	if err != nil {
+		return nil, err
+	}
+
+	metafield := &model.Metafield{
+		DefinitionID: definition.ID,
+		OwnerType:    ownerType,
+		OwnerID:      ownerID,
+		Value:        value,
+	}
+	if err := s.metafieldRepo.Upsert(metafield); err != nil {
+		return nil, err
+	}
+	metafield.Definition = definition
+	return metafield, nil
+}
+
+func (s *metafieldService) DeleteMetafield(ownerType string, ownerID uint64, namespace, key string) error {
+	if !isMetafieldOwner(ownerType) {
+		return ErrInvalidMetafieldOwner
+	}
+	definition, err := s.findDefinition(ownerType, namespace, key)
+	if err != nil {
+		return err
+	}
+	deleted, err := s.metafieldRepo.Delete(ownerType, ownerID, definition.ID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrMetafieldNotFound
+	}
+	return nil
+}
+
+func (s *metafieldService) checkOwner(ownerType string, ownerID uint64) error {
+	if !isMetafieldOwner(ownerType) {
+		return ErrInvalidMetafieldOwner
+	}
+	exists, err := s.metafieldRepo.OwnerExists(ownerType, ownerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrMetafieldOwnerNotFound
+	}
+	return nil
+}
+
+func (s *metafieldService) findDefinition(ownerType, namespace, key string) (*model.MetafieldDefinition, error) {
+	definition, err := s.metafieldRepo.FindDefinition(ownerType, strings.ToLower(namespace), strings.ToLower(key))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMetafieldDefinitionNotFound
+		}
+		return nil, err
+	}
+	return definition, nil
+}
+
+func metafieldValue(fieldType string, raw any) (any, error) {
+	invalid := fmt.Errorf("%w: expected %s", ErrInvalidMetafieldValue, fieldType)
+
+	switch fieldType {
+	case model.MetafieldTypeText:
+		text, ok := raw.(string)
+		if !ok || strings.ContainsAny(text, "\r\n") || utf8.RuneCountInString(text) > maxMetafieldLineLength {
+			return nil, invalid
+		}
+		return text, nil
+	case model.MetafieldTypeMultilineText:
+		text, ok := raw.(string)
+		if !ok || utf8.RuneCountInString(text) > maxMetafieldTextLength {
+			return nil, invalid
+		}
+		return text, nil
+	case model.MetafieldTypeInteger:
+		number, ok := raw.(float64)
+		if !ok || number != math.Trunc(number) || math.Abs(number) > maxSafeInteger {
+			return nil, invalid
+		}
+		return int64(number), nil
+	case model.MetafieldTypeDecimal:
+		number, ok := raw.(float64)
+		if !ok {
+			return nil, invalid
+		}
+		return number, nil
+	case model.MetafieldTypeBoolean:
+		flag, ok := raw.(bool)
+		if !ok {
+			return nil, invalid
+		}
+		return flag, nil
+	case model.MetafieldTypeDate:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, invalid
+		}
+		if _, err := time.Parse(time.DateOnly, text); err != nil {
+			return nil, invalid
+		}
+		return text, nil
+	case model.MetafieldTypeURL:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, invalid
+		}
+		u, err := url.Parse(text)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, invalid
+		}
+		return text, nil
+	case model.MetafieldTypeJSON:
+		switch raw.(type) {
+		case map[string]any, []any:
+			return raw, nil
+		}
+		return nil, invalid
+	}
+	return nil, invalid
+}
+
+func isMetafieldOwner(ownerType string) bool {
+	switch ownerType {
+	case model.MetafieldOwnerProduct, model.MetafieldOwnerVariant, model.MetafieldOwnerCategory, model.MetafieldOwnerMedia:
+		return true
+	}
+	return false
+}
+
+func isMetafieldType(fieldType string) bool {
+	switch fieldType {
+	case model.MetafieldTypeText, model.MetafieldTypeMultilineText, model.MetafieldTypeInteger,
+		model.MetafieldTypeDecimal, model.MetafieldTypeBoolean, model.MetafieldTypeDate,
+		model.MetafieldTypeURL, model.MetafieldTypeJSON:
+		return true
+	}
+	return false
+}
+
+func isMetafieldVisibility(visibility string) bool {
+	return visibility == model.MetafieldVisibilityPublic || visibility == model.MetafieldVisibilityAdmin
+}
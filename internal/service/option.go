@@ -0,0 +1,519 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"github.com/Durgarao310/zneha-backend/pkg/slug"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOptionTypeNotFound       = errors.New("option type not found")
+	ErrOptionValueNotFound      = errors.New("option value not found")
+	ErrVariantNotFound          = errors.New("variant not found")
+	ErrDuplicateOption          = errors.New("option type or value already exists")
+	ErrReservedOptionName       = errors.New("option names product and values are reserved for sku templates")
+	ErrInvalidOptionCode        = errors.New("option value code may only contain letters, digits and hyphens")
+	ErrOptionInUse              = errors.New("option is used by existing variants")
+	ErrInvalidOptionOrder       = errors.New("ids must list every option type or every value of the option exactly once")
+	ErrNoOptions                = errors.New("product has no option types to generate variants from")
+	ErrOptionWithoutValues      = errors.New("every option type needs at least one value")
+	ErrTooManyVariants          = errors.New("option combinations exceed the variant generation limit")
+	ErrInvalidSKUTemplate       = errors.New("sku template may only use {product}, {values} and option names, and must include {values} or every option")
+	ErrSKUTaken                 = errors.New("sku is already in use")
+	ErrSKUTooLong               = errors.New("SKU template renders too long")
+	ErrInvalidVariantOptions    = errors.New("a variant needs exactly one value from every option type of its product")
+	ErrVariantCombinationExists = errors.New("another variant already has this option combination")
+)
+
+const maxGeneratedVariants = 500
+
+// DefaultSKUTemplate joins the product slug and every value code, e.g. KURTA-RED-XL
+const DefaultSKUTemplate = "{product}-{values}"
+
+// maxSKULength matches the variant sku column
+const maxSKULength = 100
+
+var (
+	skuPlaceholder = regexp.MustCompile(`\{([^{}]+)\}`)
+	validCode      = regexp.MustCompile(`^[A-Z0-9]+(-[A-Z0-9]+)*$`)
+)
+
+type GenerateVariantsInput struct {
+	SKUTemplate   string // placeholders: {product}, {values} and {<option name>}; DefaultSKUTemplate when empty
+	Price         float64
+	StockQuantity int
+}
+
+type OptionService interface {
+	ListOptions(productID uint64) ([]model.OptionType, error)
+	CreateOption(optionType *model.OptionType) error
+	UpdateOption(optionType *model.OptionType) error
+	DeleteOption(productID, optionTypeID uint64) error
+	ReorderOptions(productID uint64, ids []uint64) ([]model.OptionType, error)
+	AddValue(productID uint64, value *model.OptionValue) error
+	DeleteValue(productID, optionTypeID, valueID uint64) error
+	ReorderValues(productID, optionTypeID uint64, ids []uint64) (*model.OptionType, error)
+	SetVariantOptions(variantID uint64, valueIDs []uint64) (*model.Variant, error)
+	GenerateVariants(productID uint64, input GenerateVariantsInput) (created []model.Variant, skipped int, err error)
+}
+
+type optionService struct {
+	optionRepo  repository.OptionRepository
+	productRepo repository.ProductRepository
+	variantRepo repository.VariantRepository
+}
+
+func NewOptionService(optionRepo repository.OptionRepository, productRepo repository.ProductRepository, variantRepo repository.VariantRepository) OptionService {
+	return &optionService{
+		optionRepo:  optionRepo,
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+	}
+}
+
+func (s *optionService) ListOptions(productID uint64) ([]model.OptionType, error) {
+	if _, err := s.product(productID); err != nil {
+		return nil, err
+	}
+	return s.optionRepo.GetTypesByProduct(productID)
+}
+
+func (s *optionService) CreateOption(optionType *model.OptionType) error {
+	if isReservedOptionName(optionType.Name) {
+		return ErrReservedOptionName
+	}
+	existing, err := s.ListOptions(optionType.ProductID)
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if strings.EqualFold(other.Name, optionType.Name) {
+			return ErrDuplicateOption
+		}
+	}
+
+	seen := make(map[string]bool, len(optionType.Values))
+	for i := range optionType.Values {
+		value := &optionType.Values[i]
+		if seen[strings.ToLower(value.Value)] {
+			return ErrDuplicateOption
+		}
+		seen[strings.ToLower(value.Value)] = true
+		if err := normalizeOptionValue(value); err != nil {
+			return err
+		}
+		value.Position = i
+	}
+
+	optionType.Position = len(existing)
+	return s.optionRepo.CreateType(optionType)
+}
+
+// UpdateOption renames an option type; positions change through ReorderOptions
+func (s *optionService) UpdateOption(optionType *model.OptionType) error {
+	if isReservedOptionName(optionType.Name) {
+		return ErrReservedOptionName
+	}
+	current, err := s.optionType(optionType.ProductID, optionType.ID)
+	if err != nil {
+		return err
+	}
+
+	siblings, err := s.optionRepo.GetTypesByProduct(optionType.ProductID)
+	if err != nil {
+		return err
+	}
+	for _, other := range siblings {
+		if other.ID != optionType.ID && strings.EqualFold(other.Name, optionType.Name) {
+			return ErrDuplicateOption
+		}
+	}
+
+	if err := s.optionRepo.UpdateType(optionType); err != nil {
+		return err
+	}
+	optionType.Position = current.Position
+	optionType.Values = current.Values
+	optionType.CreatedAt = current.CreatedAt
+	return nil
+}
+
+func (s *optionService) DeleteOption(productID, optionTypeID uint64) error {
+	if _, err := s.optionType(productID, optionTypeID); err != nil {
+		return err
+	}
+	count, err := s.optionRepo.CountVariantsUsingType(optionTypeID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrOptionInUse
+	}
+	return s.optionRepo.DeleteType(optionTypeID)
+}
+
+func (s *optionService) AddValue(productID uint64, value *model.OptionValue) error {
+	optionType, err := s.optionType(productID, value.OptionTypeID)
+	if err != nil {
+		return err
+	}
+	for _, other := range optionType.Values {
+		if strings.EqualFold(other.Value, value.Value) {
+			return ErrDuplicateOption
+		}
+	}
+	if err := normalizeOptionValue(value); err != nil {
+		return err
+	}
+
+	value.Position = len(optionType.Values)
+	return s.optionRepo.CreateValue(value)
+}
+
+func (s *optionService) DeleteValue(productID, optionTypeID, valueID uint64) error {
+	optionType, err := s.optionType(productID, optionTypeID)
+	if err != nil {
+		return err
+	}
+	if !hasValue(optionType, valueID) {
+		return ErrOptionValueNotFound
+	}
+
+	count, err := s.optionRepo.CountVariantsUsingValue(valueID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrOptionInUse
+	}
+	return s.optionRepo.DeleteValue(valueID)
+}
+
+// ReorderOptions also sets the order of value codes in generated SKUs
+func (s *optionService) ReorderOptions(productID uint64, ids []uint64) ([]model.OptionType, error) {
+	if _, err := s.product(productID); err != nil {
+		return nil, err
+	}
+	if err := s.optionRepo.ReorderTypes(productID, ids); err != nil {
+		if errors.Is(err, repository.ErrSiblingSetMismatch) {
+			return nil, ErrInvalidOptionOrder
+		}
+		return nil, err
+	}
+	return s.optionRepo.GetTypesByProduct(productID)
+}
+
+func (s *optionService) ReorderValues(productID, optionTypeID uint64, ids []uint64) (*model.OptionType, error) {
+	if _, err := s.optionType(productID, optionTypeID); err != nil {
+		return nil, err
+	}
+	if err := s.optionRepo.ReorderValues(optionTypeID, ids); err != nil {
+		if errors.Is(err, repository.ErrSiblingSetMismatch) {
+			return nil, ErrInvalidOptionOrder
+		}
+		return nil, err
+	}
+	return s.optionRepo.GetType(optionTypeID)
+}
+
+func (s *optionService) SetVariantOptions(variantID uint64, valueIDs []uint64) (*model.Variant, error) {
+	variant, err := s.variantRepo.GetByID(variantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVariantNotFound
+		}
+		return nil, err
+	}
+
+	optionTypes, err := s.optionRepo.GetTypesByProduct(variant.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.optionRepo.GetValuesByIDs(valueIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(optionTypes) == 0 || len(values) != len(valueIDs) || len(values) != len(optionTypes) {
+		return nil, ErrInvalidVariantOptions
+	}
+
+	productTypes := make(map[uint64]bool, len(optionTypes))
+	for _, optionType := range optionTypes {
+		productTypes[optionType.ID] = true
+	}
+	links := make([]model.VariantOptionValue, 0, len(values))
+	for _, value := range values {
+		if !productTypes[value.OptionTypeID] {
+			return nil, ErrInvalidVariantOptions
+		}
+		delete(productTypes, value.OptionTypeID) // a second value of the same type fails here
+		links = append(links, model.VariantOptionValue{OptionTypeID: value.OptionTypeID, OptionValueID: value.ID})
+	}
+
+	key := optionKey(valueIDs)
+	if key != variant.OptionKey {
+		taken, err := s.existingKeys(variant.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if taken[key] {
+			return nil, ErrVariantCombinationExists
+		}
+	}
+
+	if err := s.variantRepo.SetOptions(variantID, key, links); err != nil {
+		return nil, err
+	}
+	return s.variantRepo.GetByID(variantID)
+}
+
+// GenerateVariants skips combinations that already have a variant and counts them
+func (s *optionService) GenerateVariants(productID uint64, input GenerateVariantsInput) ([]model.Variant, int, error) {
+	product, err := s.product(productID)
+	if err != nil {
+		return nil, 0, err
+	}
+	optionTypes, err := s.optionRepo.GetTypesByProduct(productID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(optionTypes) == 0 {
+		return nil, 0, ErrNoOptions
+	}
+
+	total := 1
+	for _, optionType := range optionTypes {
+		if len(optionType.Values) == 0 {
+			return nil, 0, ErrOptionWithoutValues
+		}
+		total *= len(optionType.Values)
+		if total > maxGeneratedVariants {
+			return nil, 0, ErrTooManyVariants
+		}
+	}
+
+	template := input.SKUTemplate
+	if template == "" {
+		template = DefaultSKUTemplate
+	}
+	if err := validateSKUTemplate(template, optionTypes); err != nil {
+		return nil, 0, err
+	}
+
+	taken, err := s.existingKeys(productID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	productCode := strings.ToUpper(product.Slug)
+	if productCode == "" {
+		productCode = "P" + strconv.FormatUint(product.ID, 10)
+	}
+
+	var variants []model.Variant
+	skipped := 0
+	skus := make(map[string]bool)
+	for _, combination := range combinations(optionTypes) {
+		ids := make([]uint64, len(combination))
+		links := make([]model.VariantOptionValue, len(combination))
+		for i, value := range combination {
+			ids[i] = value.ID
+			links[i] = model.VariantOptionValue{OptionTypeID: value.OptionTypeID, OptionValueID: value.ID}
+		}
+
+		key := optionKey(ids)
+		if taken[key] {
+			skipped++
+			continue
+		}
+
+		sku := renderSKU(template, productCode, optionTypes, combination)
+		if len(sku) > maxSKULength {
+			return nil, 0, fmt.Errorf("%w: %s", ErrSKUTooLong, sku)
+		}
+		if skus[sku] {
+			return nil, 0, fmt.Errorf("%w: %s", ErrSKUTaken, sku)
+		}
+		skus[sku] = true
+
+		variants = append(variants, model.Variant{
+			ProductID:     productID,
+			SKU:           sku,
+			Price:         input.Price,
+			StockQuantity: input.StockQuantity,
+			IsActive:      true,
+			OptionKey:     key,
+			OptionValues:  links,
+		})
+	}
+
+	generated := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		generated = append(generated, variant.SKU)
+	}
+	existing, err := s.variantRepo.FindExistingSKUs(generated)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(existing) > 0 {
+		return nil, 0, fmt.Errorf("%w: %s", ErrSKUTaken, strings.Join(existing, ", "))
+	}
+
+	if err := s.variantRepo.CreateWithOptions(variants); err != nil {
+		if repository.IsUniqueViolation(err, "idx_variant_sku") {
+			return nil, 0, ErrSKUTaken
+		}
+		return nil, 0, err
+	}
+	if variants == nil {
+		variants = []model.Variant{}
+	}
+	return variants, skipped, nil
+}
+
+func (s *optionService) product(productID uint64) (*model.Product, error) {
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+	return product, nil
+}
+
+func (s *optionService) optionType(productID, optionTypeID uint64) (*model.OptionType, error) {
+	optionType, err := s.optionRepo.GetType(optionTypeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOptionTypeNotFound
+		}
+		return nil, err
+	}
+	if optionType.ProductID != productID {
+		return nil, ErrOptionTypeNotFound
+	}
+	return optionType, nil
+}
+
+func (s *optionService) existingKeys(productID uint64) (map[string]bool, error) {
+	keys, err := s.variantRepo.GetOptionKeys(productID)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		taken[key] = true
+	}
+	return taken, nil
+}
+
+func normalizeOptionValue(value *model.OptionValue) error {
+	value.Value = strings.TrimSpace(value.Value)
+	value.Code = strings.ToUpper(strings.TrimSpace(value.Code))
+	if value.Code == "" {
+		value.Code = strings.ToUpper(slug.Make(value.Value))
+	}
+	if !validCode.MatchString(value.Code) {
+		return ErrInvalidOptionCode
+	}
+	return nil
+}
+
+func isReservedOptionName(name string) bool {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return name == "product" || name == "values"
+}
+
+func hasValue(optionType *model.OptionType, valueID uint64) bool {
+	for _, value := range optionType.Values {
+		if value.ID == valueID {
+			return true
+		}
+	}
+	return false
+}
+
+// optionKey identifies a combination of option values independent of their order
+func optionKey(valueIDs []uint64) string {
+	sorted := append([]uint64(nil), valueIDs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	parts := make([]string, len(sorted))
+	for i, id := range sorted {
+		parts[i] = strconv.FormatUint(id, 10)
+	}
+	return strings.Join(parts, "-")
+}
+
+// combinations varies the last option fastest, following the display order
+func combinations(optionTypes []model.OptionType) [][]model.OptionValue {
+	result := [][]model.OptionValue{{}}
+	for _, optionType := range optionTypes {
+		next := make([][]model.OptionValue, 0, len(result)*len(optionType.Values))
+		for _, prefix := range result {
+			for _, value := range optionType.Values {
+				combination := append(append([]model.OptionValue(nil), prefix...), value)
+				next = append(next, combination)
+			}
+		}
+		result = next
+	}
+	return result
+}
+
+// validateSKUTemplate also requires the template to tell all combinations apart
+func validateSKUTemplate(template string, optionTypes []model.OptionType) error {
+	used := make(map[string]bool)
+	for _, match := range skuPlaceholder.FindAllStringSubmatch(template, -1) {
+		name := strings.ToLower(match[1])
+		known := name == "product" || name == "values"
+		for _, optionType := range optionTypes {
+			if strings.ToLower(optionType.Name) == name {
+				known = true
+			}
+		}
+		if !known {
+			return ErrInvalidSKUTemplate
+		}
+		used[name] = true
+	}
+
+	if used["values"] {
+		return nil
+	}
+	for _, optionType := range optionTypes {
+		if !used[strings.ToLower(optionType.Name)] {
+			return ErrInvalidSKUTemplate
+		}
+	}
+	return nil
+}
+
+func renderSKU(template, productCode string, optionTypes []model.OptionType, combination []model.OptionValue) string {
+	codes := make([]string, len(combination))
+	byName := make(map[string]string, len(combination))
+	for i, value := range combination {
+		codes[i] = value.Code
+		byName[strings.ToLower(optionTypes[i].Name)] = value.Code
+	}
+
+	return skuPlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		switch name := strings.ToLower(placeholder[1 : len(placeholder)-1]); name {
+		case "product":
+			return productCode
+		case "values":
+			return strings.Join(codes, "-")
+		default:
+			return byName[name]
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"github.com/Durgarao310/zneha-backend/pkg/hash"
+	"github.com/Durgarao310/zneha-backend/pkg/validator"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidPhone       = errors.New("invalid mobile number")
+	ErrInvalidOTP         = errors.New("invalid or expired code")
+	ErrTooManyOTPRequests = errors.New("too many codes requested for this number, try again later")
+)
+
+const otpDigits = 6
+
+// RequestPhoneOTP texts a login code to an Indian mobile number
+func (s *authService) RequestPhoneOTP(phone string, client ClientInfo) (time.Time, error) {
+	phone, ok := validator.NormalizeIndianPhone(phone)
+	if !ok {
+		return time.Time{}, ErrInvalidPhone
+	}
+
+	code, err := generateOTP()
+	if err != nil {
+		return time.Time{}, err
+	}
+	codeHash, err := hash.HashPassword(code)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	otp := &model.PhoneOTP{
+		Phone:     phone,
+		CodeHash:  codeHash,
+		ExpiresAt: time.Now().Add(s.options.OTPTTL),
+		IPAddress: client.IPAddress,
+	}
+	wait, err := s.phoneOTPRepo.Issue(otp, repository.OTPRateLimit{
+		ResendInterval: s.options.OTPResendInterval,
+		PerHour:        s.options.OTPRequestsPerHour,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if wait > 0 {
+		return time.Time{}, &ThrottleError{Err: ErrTooManyOTPRequests, RetryAfter: wait}
+	}
+
+	message := fmt.Sprintf("%s is your login code. It expires in %d minutes. Do not share it with anyone.",
+		code, int(s.options.OTPTTL.Minutes()))
+	if err := s.smsSender.Send(phone, message); err != nil {
+		return time.Time{}, err
+	}
+
+	return otp.ExpiresAt, nil
+}
+
+// VerifyPhoneOTP signs in with a code, creating the account on first login
+func (s *authService) VerifyPhoneOTP(phone, code string, client ClientInfo) (*AuthResult, error) {
+	phone, ok := validator.NormalizeIndianPhone(phone)
+	if !ok {
+		return nil, ErrInvalidPhone
+	}
+
+	if err := s.loginThrottle.Check(phone, client.IPAddress); err != nil {
+		return nil, err
+	}
+
+	otp, err := s.phoneOTPRepo.GetLatestActive(phone)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, s.loginFailed(phone, client, nil, ErrInvalidOTP)
+		}
+		return nil, err
+	}
+
+	// Use up an attempt before comparing the code
+	attempts, err := s.phoneOTPRepo.IncrementAttempts(otp.ID, s.options.OTPMaxAttempts)
+	if err != nil {
+		if errors.Is(err, repository.ErrTokenAlreadyUsed) {
+			return nil, s.loginFailed(phone, client, nil, ErrInvalidOTP)
+		}
+		return nil, err
+	}
+	if !hash.CheckPassword(otp.CodeHash, code) {
+		if attempts >= s.options.OTPMaxAttempts {
+			if err := s.phoneOTPRepo.Consume(otp.ID); err != nil && !errors.Is(err, repository.ErrTokenAlreadyUsed) {
+				return nil, err
+			}
+		}
+		return nil, s.loginFailed(phone, client, nil, ErrInvalidOTP)
+	}
+
+	if err := s.phoneOTPRepo.Consume(otp.ID); err != nil {
+		if errors.Is(err, repository.ErrTokenAlreadyUsed) {
+			return nil, ErrInvalidOTP
+		}
+		return nil, err
+	}
+
+	user, err := s.findOrCreatePhoneUser(phone)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return s.mfaChallenge(user)
+	}
+
+	return s.completeLogin(phone, user, client, false)
+}
+
+func (s *authService) findOrCreatePhoneUser(phone string) (*model.User, error) {
+	now := time.Now()
+
+	user, err := s.userRepo.GetByPhone(phone)
+	if err == nil {
+		if user.PhoneVerifiedAt == nil {
+			if err := s.userRepo.MarkPhoneVerified(user.ID, now); err != nil {
+				return nil, err
+			}
+			user.PhoneVerifiedAt = &now
+		}
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	user = &model.User{
+		Phone:           &phone,
+		PhoneVerifiedAt: &now,
+		IsActive:        true,
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		if repository.IsUniqueViolation(err, "idx_user_phone") {
+			return s.userRepo.GetByPhone(phone)
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func generateOTP() (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(otpDigits), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", otpDigits, n), nil
+}
@@ -1,29 +1,66 @@
 package service
 
 import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
 	"github.com/Durgarao310/zneha-backend/internal/model"
 	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrProductNotFound            = errors.New("product not found")
+	ErrUnknownCategory            = errors.New("one or more categories do not exist")
+	ErrPrimaryCategoryNotAssigned = errors.New("primary category must be one of the assigned categories")
+	ErrUnknownBrand               = errors.New("brand does not exist")
+	ErrInvalidSearchQuery         = errors.New("search query must be 1-200 characters")
+	ErrInvalidPriceRange          = errors.New("minimum price must not exceed maximum price")
+	ErrUnknownAttributeFilter     = errors.New("unknown attribute filter")
 )
 
 type ProductService interface {
-	Create(product *model.Product) error
+	Create(product *model.Product, categoryIDs []uint64, primaryCategoryID *uint64) error
 	GetAll() ([]model.Product, error)
 	GetByID(id uint64) (*model.Product, error)
-	Update(product *model.Product) error
+	GetBySlug(slug string) (product *model.Product, moved bool, err error)
+	Update(product *model.Product, categoryIDs []uint64, primaryCategoryID *uint64) error
 	Delete(id uint64) error
-	GetWithPagination(page, limit int) ([]model.Product, int64, error)
+	List(input ProductListInput, params pagination.PaginationParams) ([]model.Product, pagination.PageInfo, *repository.ProductFacets, error)
+	GetByCategory(categoryID uint64, includeDescendants bool, page, limit int) ([]model.Product, int64, error)
+	Search(query string, page, limit int) ([]repository.ProductSearchHit, int64, error)
 }
 
 type productService struct {
-	repo repository.ProductRepository
+	repo          repository.ProductRepository
+	categoryRepo  repository.CategoryRepository
+	slugRepo      repository.SlugRedirectRepository
+	brandRepo     repository.BrandRepository
+	attributeRepo repository.AttributeRepository
 }
 
-func NewProductService(repo repository.ProductRepository) ProductService {
-	return &productService{repo}
+func NewProductService(repo repository.ProductRepository, categoryRepo repository.CategoryRepository, slugRepo repository.SlugRedirectRepository, brandRepo repository.BrandRepository, attributeRepo repository.AttributeRepository) ProductService {
+	return &productService{repo, categoryRepo, slugRepo, brandRepo, attributeRepo}
 }
 
-func (s *productService) Create(product *model.Product) error {
-	return s.repo.Create(product)
+func (s *productService) Create(product *model.Product, categoryIDs []uint64, primaryCategoryID *uint64) error {
+	if err := s.checkBrand(product.BrandID); err != nil {
+		return err
+	}
+	links, err := s.categoryLinks(categoryIDs, primaryCategoryID)
+	if err != nil {
+		return err
+	}
+
+	product.Slug, err = resolveSlug(s.slugRepo, model.SlugEntityProduct, 0, product.Slug, product.Name)
+	if err != nil {
+		return err
+	}
+
+	product.Categories = links
+	return slugConflict(model.SlugEntityProduct, s.repo.Create(product))
 }
 
 func (s *productService) GetAll() ([]model.Product, error) {
@@ -34,14 +71,145 @@ func (s *productService) GetByID(id uint64) (*model.Product, error) {
 	return s.repo.FindByID(id)
 }
 
-func (s *productService) Update(product *model.Product) error {
-	return s.repo.Update(product)
+// GetBySlug also resolves earlier slugs, reporting moved for them
+func (s *productService) GetBySlug(slug string) (*model.Product, bool, error) {
+	slug = strings.ToLower(slug)
+	product, err := s.repo.FindBySlug(slug)
+	if err == nil {
+		return product, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, err
+	}
+
+	redirect, err := s.slugRepo.Find(model.SlugEntityProduct, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, ErrProductNotFound
+		}
+		return nil, false, err
+	}
+	product, err = s.repo.FindByID(redirect.EntityID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, ErrProductNotFound
+		}
+		return nil, false, err
+	}
+	return product, true, nil
+}
+
+// Update keeps the assignments for nil categoryIDs and the slug when it is empty
+func (s *productService) Update(product *model.Product, categoryIDs []uint64, primaryCategoryID *uint64) error {
+	existing, err := s.repo.FindByID(product.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProductNotFound
+		}
+		return err
+	}
+	product.CreatedAt = existing.CreatedAt
+	if err := s.checkBrand(product.BrandID); err != nil {
+		return err
+	}
+
+	switch {
+	case product.Slug != "":
+		product.Slug, err = resolveSlug(s.slugRepo, model.SlugEntityProduct, product.ID, product.Slug, product.Name)
+	case existing.Slug != "":
+		product.Slug = existing.Slug
+	default:
+		product.Slug, err = resolveSlug(s.slugRepo, model.SlugEntityProduct, product.ID, "", product.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if categoryIDs == nil {
+		return slugConflict(model.SlugEntityProduct, s.repo.Update(product))
+	}
+
+	links, err := s.categoryLinks(categoryIDs, primaryCategoryID)
+	if err != nil {
+		return err
+	}
+	return slugConflict(model.SlugEntityProduct, s.repo.UpdateWithCategories(product, links))
 }
 
 func (s *productService) Delete(id uint64) error {
 	return s.repo.Delete(id)
 }
 
-func (s *productService) GetWithPagination(page, limit int) ([]model.Product, int64, error) {
-	return s.repo.FindWithPagination(page, limit)
+func (s *productService) GetByCategory(categoryID uint64, includeDescendants bool, page, limit int) ([]model.Product, int64, error) {
+	categoryIDs := []uint64{categoryID}
+	if includeDescendants {
+		ids, err := s.categoryRepo.GetDescendantIDs(categoryID)
+		if err != nil {
+			return nil, 0, err
+		}
+		categoryIDs = ids
+	}
+	return s.repo.FindByCategoryIDs(categoryIDs, page, limit)
+}
+
+// maxSearchQueryLength bounds the text handed to the full-text parser
+const maxSearchQueryLength = 200
+
+func (s *productService) Search(query string, page, limit int) ([]repository.ProductSearchHit, int64, error) {
+	query = strings.TrimSpace(query)
+	if query == "" || utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, 0, ErrInvalidSearchQuery
+	}
+	return s.repo.Search(query, page, limit)
+}
+
+// Without an explicit primary category the first one is primary
+func (s *productService) categoryLinks(categoryIDs []uint64, primaryCategoryID *uint64) ([]model.ProductCategory, error) {
+	unique := make([]uint64, 0, len(categoryIDs))
+	seen := make(map[uint64]bool, len(categoryIDs))
+	for _, id := range categoryIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	if primaryCategoryID != nil && !seen[*primaryCategoryID] {
+		return nil, ErrPrimaryCategoryNotAssigned
+	}
+	if len(unique) == 0 {
+		return []model.ProductCategory{}, nil
+	}
+
+	count, err := s.categoryRepo.CountByIDs(unique)
+	if err != nil {
+		return nil, err
+	}
+	if count != int64(len(unique)) {
+		return nil, ErrUnknownCategory
+	}
+
+	primary := unique[0]
+	if primaryCategoryID != nil {
+		primary = *primaryCategoryID
+	}
+
+	links := make([]model.ProductCategory, 0, len(unique))
+	for _, id := range unique {
+		links = append(links, model.ProductCategory{CategoryID: id, IsPrimary: id == primary})
+	}
+	return links, nil
+}
+
+func (s *productService) checkBrand(brandID *uint64) error {
+	if brandID == nil {
+		return nil
+	}
+	if _, err := s.brandRepo.GetByID(*brandID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUnknownBrand
+		}
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// ProductListInput maps attribute codes to comma-separated alternatives for enums
+// and texts, true or false for booleans and a number or min..max range for numbers.
+type ProductListInput struct {
+	BrandIDs    []uint64
+	CategoryIDs []uint64
+	Status      string
+	MinPrice    *float64
+	MaxPrice    *float64
+	InStock     bool
+	Attributes  map[string]string
+	Query       pagination.QuerySpec
+}
+
+// List returns nil facets on later cursor pages, where the filters cannot change
+func (s *productService) List(input ProductListInput, params pagination.PaginationParams) ([]model.Product, pagination.PageInfo, *repository.ProductFacets, error) {
+	var info pagination.PageInfo
+	filter, err := s.productFilter(input)
+	if err != nil {
+		return nil, info, nil, err
+	}
+
+	products, info, err := s.repo.FindWithPagination(filter, params)
+	if err != nil {
+		return nil, info, nil, err
+	}
+	if params.UseCursor && params.Cursor != "" {
+		return products, info, nil, nil
+	}
+	facets, err := s.repo.Facets(filter)
+	if err != nil {
+		return nil, info, nil, err
+	}
+	return products, info, facets, nil
+}
+
+func (s *productService) productFilter(input ProductListInput) (repository.ProductFilter, error) {
+	filter := repository.ProductFilter{
+		BrandIDs: input.BrandIDs,
+		Status:   input.Status,
+		MinPrice: input.MinPrice,
+		MaxPrice: input.MaxPrice,
+		InStock:  input.InStock,
+		Query:    input.Query,
+	}
+	if input.MinPrice != nil && input.MaxPrice != nil && *input.MinPrice > *input.MaxPrice {
+		return filter, ErrInvalidPriceRange
+	}
+	if len(input.CategoryIDs) > pagination.MaxFilters {
+		return filter, fmt.Errorf("%w: at most %d categories", pagination.ErrInvalidQuery, pagination.MaxFilters)
+	}
+	if len(input.Attributes) > pagination.MaxFilters {
+		return filter, fmt.Errorf("%w: at most %d attribute filters", pagination.ErrInvalidQuery, pagination.MaxFilters)
+	}
+
+	seen := make(map[uint64]bool)
+	for _, categoryID := range input.CategoryIDs {
+		ids, err := s.categoryRepo.GetDescendantIDs(categoryID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return filter, ErrUnknownCategory
+			}
+			return filter, err
+		}
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				filter.CategoryIDs = append(filter.CategoryIDs, id)
+			}
+		}
+	}
+
+	codes := make([]string, 0, len(input.Attributes))
+	normalized := make([]string, 0, len(input.Attributes))
+	for code := range input.Attributes {
+		codes = append(codes, code)
+		normalized = append(normalized, strings.ToLower(strings.TrimSpace(code)))
+	}
+	sort.Strings(codes)
+	attributes, err := s.attributeRepo.GetByCodes(normalized)
+	if err != nil {
+		return filter, err
+	}
+	byCode := make(map[string]*model.Attribute, len(attributes))
+	for i := range attributes {
+		byCode[attributes[i].Code] = &attributes[i]
+	}
+
+	for _, code := range codes {
+		attribute, ok := byCode[strings.ToLower(strings.TrimSpace(code))]
+		if !ok {
+			return filter, fmt.Errorf("%w: %s", ErrUnknownAttributeFilter, code)
+		}
+		attributeFilter, err := attributeFilter(attribute, input.Attributes[code])
+		if err != nil {
+			return filter, err
+		}
+		filter.Attributes = append(filter.Attributes, attributeFilter)
+	}
+	return filter, nil
+}
+
+func attributeFilter(attribute *model.Attribute, raw string) (repository.AttributeFilter, error) {
+	filter := repository.AttributeFilter{AttributeID: attribute.ID}
+	invalid := fmt.Errorf("%w: %s", ErrInvalidAttributeValue, attribute.Code)
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return filter, invalid
+	}
+
+	switch attribute.Type {
+	case model.AttributeTypeEnum:
+		for _, value := range strings.Split(raw, ",") {
+			value = strings.TrimSpace(value)
+			found := false
+			for i := range attribute.Options {
+				if strings.EqualFold(attribute.Options[i].Value, value) {
+					filter.OptionIDs = append(filter.OptionIDs, attribute.Options[i].ID)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return filter, invalid
+			}
+		}
+	case model.AttributeTypeBoolean:
+		flag, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, invalid
+		}
+		filter.Bool = &flag
+	case model.AttributeTypeNumber:
+		low, high, ok := strings.Cut(raw, "..")
+		if !ok {
+			// A single number matches exactly
+			high = low
+		}
+		var err error
+		if filter.Min, err = parseBound(low); err != nil {
+			return filter, invalid
+		}
+		if filter.Max, err = parseBound(high); err != nil {
+			return filter, invalid
+		}
+		if filter.Min == nil && filter.Max == nil {
+			return filter, invalid
+		}
+		if filter.Min != nil && filter.Max != nil && *filter.Min > *filter.Max {
+			return filter, invalid
+		}
+	case model.AttributeTypeText:
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+				filter.Texts = append(filter.Texts, value)
+			}
+		}
+		if len(filter.Texts) == 0 {
+			return filter, invalid
+		}
+	default:
+		return filter, invalid
+	}
+	return filter, nil
+}
+
+// parseBound parses one end of a number range, nil when left open
+func parseBound(text string) (*float64, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+	number, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return nil, fmt.Errorf("not a finite number: %s", text)
+	}
+	return &number, nil
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+)
+
+var (
+	ErrUnknownPermission = errors.New("unknown permission")
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrPermissionNotHeld = errors.New("cannot grant a permission you do not hold")
+)
+
+type UserAccess struct {
+	Permissions []string
+	RequireMFA  bool // at least one role requires two-factor authentication
+}
+
+type RBACService interface {
+	CreateRole(name, description string, requireMFA bool, permissions []string, granted map[string]bool) (*model.Role, error)
+	UpdateRole(id uint64, name, description string, requireMFA bool, permissions []string, granted map[string]bool) (*model.Role, error)
+	DeleteRole(id uint64) error
+	GetRole(id uint64) (*model.Role, error)
+	ListRoles() ([]model.Role, error)
+	ListPermissions() ([]model.Permission, error)
+	AssignRole(userID, roleID uint64, granted map[string]bool) error
+	RevokeRole(userID, roleID uint64) error
+	GetUserRoles(userID uint64) ([]model.Role, error)
+	GetUserPermissions(userID uint64) ([]string, error)
+	GetUserAccess(userID uint64) (*UserAccess, error)
+}
+
+type rbacService struct {
+	roleRepo repository.RoleRepository
+	userRepo repository.UserRepository
+}
+
+func NewRBACService(roleRepo repository.RoleRepository, userRepo repository.UserRepository) RBACService {
+	return &rbacService{
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *rbacService) CreateRole(name, description string, requireMFA bool, permissions []string, granted map[string]bool) (*model.Role, error) {
+	perms, err := s.resolvePermissions(permissions, granted)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &model.Role{
+		Name:        strings.TrimSpace(name),
+		Description: description,
+		RequireMFA:  requireMFA,
+		Permissions: perms,
+	}
+	if err := s.roleRepo.Create(role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (s *rbacService) UpdateRole(id uint64, name, description string, requireMFA bool, permissions []string, granted map[string]bool) (*model.Role, error) {
+	role, err := s.roleRepo.GetByID(id)
+	if err != nil {
+		return nil, ErrRoleNotFound
+	}
+
+	if err := checkHeld(role.Permissions, granted); err != nil {
+		return nil, err
+	}
+	perms, err := s.resolvePermissions(permissions, granted)
+	if err != nil {
+		return nil, err
+	}
+
+	role.Name = strings.TrimSpace(name)
+	role.Description = description
+	role.RequireMFA = requireMFA
+	if err := s.roleRepo.Update(role); err != nil {
+		return nil, err
+	}
+	if err := s.roleRepo.ReplacePermissions(role, perms); err != nil {
+		return nil, err
+	}
+	role.Permissions = perms
+	return role, nil
+}
+
+func (s *rbacService) DeleteRole(id uint64) error {
+	if _, err := s.roleRepo.GetByID(id); err != nil {
+		return ErrRoleNotFound
+	}
+	return s.roleRepo.Delete(id)
+}
+
+func (s *rbacService) GetRole(id uint64) (*model.Role, error) {
+	return s.roleRepo.GetByID(id)
+}
+
+func (s *rbacService) ListRoles() ([]model.Role, error) {
+	return s.roleRepo.GetAll()
+}
+
+func (s *rbacService) ListPermissions() ([]model.Permission, error) {
+	return s.roleRepo.GetAllPermissions()
+}
+
+func (s *rbacService) AssignRole(userID, roleID uint64, granted map[string]bool) error {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return ErrUserNotFound
+	}
+	role, err := s.roleRepo.GetByID(roleID)
+	if err != nil {
+		return ErrRoleNotFound
+	}
+	if err := checkHeld(role.Permissions, granted); err != nil {
+		return err
+	}
+	return s.roleRepo.AssignToUser(userID, roleID)
+}
+
+func (s *rbacService) RevokeRole(userID, roleID uint64) error {
+	return s.roleRepo.RemoveFromUser(userID, roleID)
+}
+
+func (s *rbacService) GetUserRoles(userID uint64) ([]model.Role, error) {
+	return s.roleRepo.GetByUserID(userID)
+}
+
+func (s *rbacService) GetUserPermissions(userID uint64) ([]string, error) {
+	return s.roleRepo.GetPermissionNamesByUserID(userID)
+}
+
+func (s *rbacService) GetUserAccess(userID uint64) (*UserAccess, error) {
+	roles, err := s.roleRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	access := &UserAccess{Permissions: []string{}}
+	seen := make(map[string]bool)
+	for _, role := range roles {
+		if role.RequireMFA {
+			access.RequireMFA = true
+		}
+		for _, permission := range role.Permissions {
+			if !seen[permission.Name] {
+				seen[permission.Name] = true
+				access.Permissions = append(access.Permissions, permission.Name)
+			}
+		}
+	}
+	return access, nil
+}
+
+// resolvePermissions rejects unknown names and those the actor does not hold
+func (s *rbacService) resolvePermissions(names []string, granted map[string]bool) ([]model.Permission, error) {
+	unique := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if _, ok := model.AllPermissions[name]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPermission, name)
+		}
+		if !granted[name] {
+			return nil, fmt.Errorf("%w: %s", ErrPermissionNotHeld, name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+
+	perms, err := s.roleRepo.GetPermissionsByNames(unique)
+	if err != nil {
+		return nil, err
+	}
+	if len(perms) != len(unique) {
+		return nil, fmt.Errorf("%w: permissions have not been migrated", ErrUnknownPermission)
+	}
+	return perms, nil
+}
+
+// checkHeld rejects a role carrying a permission the actor does not hold
+func checkHeld(permissions []model.Permission, granted map[string]bool) error {
+	for _, permission := range permissions {
+		if !granted[permission.Name] {
+			return fmt.Errorf("%w: %s", ErrPermissionNotHeld, permission.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+)
+
+var ErrInvalidSuggestQuery = errors.New("suggest query must be 2-100 characters")
+
+const (
+	minSuggestQueryLength = 2
+	maxSuggestQueryLength = 100
+)
+
+type SearchService interface {
+	Suggest(query string, limit int) ([]repository.SearchSuggestion, error)
+}
+
+type searchService struct {
+	searchRepo repository.SearchRepository
+}
+
+func NewSearchService(searchRepo repository.SearchRepository) SearchService {
+	return &searchService{searchRepo: searchRepo}
+}
+
+func (s *searchService) Suggest(query string, limit int) ([]repository.SearchSuggestion, error) {
+	query = strings.ToLower(strings.Join(strings.Fields(query), " "))
+	length := utf8.RuneCountInString(query)
+	if length < minSuggestQueryLength || length > maxSuggestQueryLength {
+		return nil, ErrInvalidSuggestQuery
+	}
+	return s.searchRepo.Suggest(query, limit)
+}
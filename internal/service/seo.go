@@ -0,0 +1,179 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"github.com/Durgarao310/zneha-backend/pkg/seo"
+	"gorm.io/gorm"
+)
+
+// metaDescriptionLength is roughly what search engines show in a snippet
+const metaDescriptionLength = 160
+
+type SEOOptions struct {
+	BaseURL  string // storefront origin that product URLs and relative media URLs are resolved against
+	SiteName string
+	Currency string // ISO 4217 code of all prices
+}
+
+type ProductSEO struct {
+	Title        string        `json:"title"`
+	Description  string        `json:"description"`
+	CanonicalURL string        `json:"canonicalUrl"`
+	JSONLD       seo.Product   `json:"jsonLd"`
+	OpenGraph    []seo.MetaTag `json:"openGraph"`
+}
+
+type SEOService interface {
+	GetProductSEO(productID uint64) (*ProductSEO, error)
+}
+
+type seoService struct {
+	productRepo  repository.ProductRepository
+	categoryRepo repository.CategoryRepository
+	variantRepo  repository.VariantRepository
+	mediaRepo    repository.MediaRepository
+	options      SEOOptions
+}
+
+func NewSEOService(productRepo repository.ProductRepository, categoryRepo repository.CategoryRepository, variantRepo repository.VariantRepository, mediaRepo repository.MediaRepository, options SEOOptions) SEOService {
+	options.BaseURL = strings.TrimRight(options.BaseURL, "/")
+	return &seoService{
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+		variantRepo:  variantRepo,
+		mediaRepo:    mediaRepo,
+		options:      options,
+	}
+}
+
+// One active variant becomes an Offer, several an AggregateOffer over their price range
+func (s *seoService) GetProductSEO(productID uint64) (*ProductSEO, error) {
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	variants, err := s.variantRepo.GetActiveByProductID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	var image *model.Media
+	if media, err := s.mediaRepo.GetPrimaryByProductID(productID); err == nil {
+		image = media
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	result := &ProductSEO{
+		Title:        firstNonEmpty(product.MetaTitle, product.Name),
+		Description:  seo.Truncate(firstNonEmpty(product.MetaDescription, product.ShortDescription, product.Description), metaDescriptionLength),
+		CanonicalURL: firstNonEmpty(product.CanonicalURL, s.productURL(product)),
+	}
+
+	ld := seo.NewProduct(product.Name)
+	ld.Description = result.Description
+	ld.URL = result.CanonicalURL
+	ld.Category = s.primaryCategoryName(product)
+	if product.Brand != nil {
+		ld.Brand = &seo.Brand{Type: "Brand", Name: product.Brand.Name}
+	}
+	if image != nil {
+		ld.Image = []string{s.absoluteURL(image.URL)}
+	}
+
+	var low, high float64
+	inStock := false
+	offers := make([]seo.Offer, 0, len(variants))
+	for i, variant := range variants {
+		offers = append(offers, seo.NewOffer(variant.SKU, variant.Price, s.options.Currency, variant.StockQuantity, result.CanonicalURL))
+		if i == 0 || variant.Price < low {
+			low = variant.Price
+		}
+		if i == 0 || variant.Price > high {
+			high = variant.Price
+		}
+		inStock = inStock || variant.StockQuantity > 0
+	}
+	if len(offers) == 1 {
+		ld.SKU = offers[0].SKU
+		ld.Offers = offers[0]
+	} else if len(offers) > 1 {
+		ld.Offers = seo.NewAggregateOffer(offers, low, high, s.options.Currency)
+	}
+	result.JSONLD = ld
+
+	tags := []seo.MetaTag{
+		{Property: "og:type", Content: "product"},
+		{Property: "og:title", Content: result.Title},
+		{Property: "og:description", Content: result.Description},
+		{Property: "og:url", Content: result.CanonicalURL},
+		{Property: "og:site_name", Content: s.options.SiteName},
+	}
+	if image != nil {
+		tags = append(tags, seo.MetaTag{Property: "og:image", Content: s.absoluteURL(image.URL)})
+		if image.Alt != "" {
+			tags = append(tags, seo.MetaTag{Property: "og:image:alt", Content: image.Alt})
+		}
+	}
+	if len(offers) > 0 {
+		availability := "out of stock"
+		if inStock {
+			availability = "in stock"
+		}
+		tags = append(tags,
+			seo.MetaTag{Property: "product:price:amount", Content: seo.FormatPrice(low)},
+			seo.MetaTag{Property: "product:price:currency", Content: s.options.Currency},
+			seo.MetaTag{Property: "product:availability", Content: availability},
+		)
+	}
+	result.OpenGraph = tags
+
+	return result, nil
+}
+
+func (s *seoService) productURL(product *model.Product) string {
+	if product.Slug != "" {
+		return s.options.BaseURL + "/products/" + product.Slug
+	}
+	return s.options.BaseURL + "/products/" + strconv.FormatUint(product.ID, 10)
+}
+
+// absoluteURL resolves media stored with a relative URL against the storefront
+func (s *seoService) absoluteURL(url string) string {
+	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+		return url
+	}
+	return s.options.BaseURL + "/" + strings.TrimLeft(url, "/")
+}
+
+func (s *seoService) primaryCategoryName(product *model.Product) string {
+	for _, link := range product.Categories {
+		if !link.IsPrimary {
+			continue
+		}
+		category, err := s.categoryRepo.GetByID(link.CategoryID)
+		if err != nil {
+			return ""
+		}
+		return category.Name
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
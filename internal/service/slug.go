@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"github.com/Durgarao310/zneha-backend/pkg/slug"
+)
+
+var (
+	ErrInvalidSlug = errors.New("slug may only contain lowercase letters, digits and single hyphens")
+	ErrSlugTaken   = errors.New("slug is already in use")
+)
+
+const maxSlugSuffix = 1000
+
+var slugIndexes = map[string]string{
+	model.SlugEntityProduct:  "idx_product_slug",
+	model.SlugEntityCategory: "idx_category_slug",
+	model.SlugEntityBrand:    "idx_brand_slug",
+}
+
+// resolveSlug uses a requested slug as given, or generates a free one from name.
+// entityID is 0 for entities not created yet.
+func resolveSlug(redirects repository.SlugRedirectRepository, entityType string, entityID uint64, requested, name string) (string, error) {
+	if requested != "" {
+		requested = strings.ToLower(requested)
+		if !slug.Valid(requested) {
+			return "", ErrInvalidSlug
+		}
+		taken, err := redirects.IsTaken(entityType, requested, entityID)
+		if err != nil {
+			return "", err
+		}
+		if taken {
+			return "", ErrSlugTaken
+		}
+		return requested, nil
+	}
+
+	base := slug.Make(name)
+	if base == "" {
+		base = entityType
+	}
+	for n := 1; n <= maxSlugSuffix; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = slug.WithSuffix(base, n)
+		}
+		taken, err := redirects.IsTaken(entityType, candidate, entityID)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+	return "", ErrSlugTaken
+}
+
+// slugConflict maps a unique violation on the slug index to ErrSlugTaken
+func slugConflict(entityType string, err error) error {
+	if repository.IsUniqueViolation(err, slugIndexes[entityType]) {
+		return ErrSlugTaken
+	}
+	return err
+}
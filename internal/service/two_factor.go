@@ -0,0 +1,188 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"github.com/Durgarao310/zneha-backend/pkg/hash"
+	"github.com/Durgarao310/zneha-backend/pkg/totp"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotStarted     = errors.New("two-factor enrollment has not been started")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+)
+
+const (
+	recoveryCodeCount = 10
+	recoveryCodeBytes = 6 // 10 base32 characters per code
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type TOTPEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+type TwoFactorService interface {
+	BeginEnrollment(userID uint64) (*TOTPEnrollment, error)
+	ConfirmEnrollment(userID uint64, code string) ([]string, error)
+	Disable(userID uint64, code string) error
+	RegenerateRecoveryCodes(userID uint64, code string) ([]string, error)
+	VerifyCode(user *model.User, code string) (bool, error)
+}
+
+type twoFactorService struct {
+	userRepo         repository.UserRepository
+	recoveryCodeRepo repository.RecoveryCodeRepository
+	issuer           string
+}
+
+func NewTwoFactorService(userRepo repository.UserRepository, recoveryCodeRepo repository.RecoveryCodeRepository, issuer string) TwoFactorService {
+	return &twoFactorService{
+		userRepo:         userRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		issuer:           issuer,
+	}
+}
+
+// BeginEnrollment generates a secret that takes effect once confirmed
+func (s *twoFactorService) BeginEnrollment(userID uint64) (*TOTPEnrollment, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.TOTPEnabledAt != nil {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.UpdateTOTP(user.ID, secret, nil, 0); err != nil {
+		return nil, err
+	}
+
+	return &TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.issuer, accountLabel(user), secret),
+	}, nil
+}
+
+func (s *twoFactorService) ConfirmEnrollment(userID uint64, code string) ([]string, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.TOTPEnabledAt != nil {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTwoFactorNotStarted
+	}
+	step, ok := totp.Validate(code, user.TOTPSecret, time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	now := time.Now()
+	if err := s.userRepo.UpdateTOTP(user.ID, user.TOTPSecret, &now, step); err != nil {
+		return nil, err
+	}
+	return s.issueRecoveryCodes(user.ID)
+}
+
+func (s *twoFactorService) Disable(userID uint64, code string) error {
+	user, err := s.requireEnabled(userID, code)
+	if err != nil {
+		return err
+	}
+	if err := s.userRepo.UpdateTOTP(user.ID, "", nil, 0); err != nil {
+		return err
+	}
+	return s.recoveryCodeRepo.DeleteForUser(user.ID)
+}
+
+func (s *twoFactorService) RegenerateRecoveryCodes(userID uint64, code string) ([]string, error) {
+	user, err := s.requireEnabled(userID, code)
+	if err != nil {
+		return nil, err
+	}
+	return s.issueRecoveryCodes(user.ID)
+}
+
+// VerifyCode accepts either a current TOTP code or an unused recovery code
+func (s *twoFactorService) VerifyCode(user *model.User, code string) (bool, error) {
+	if user.TOTPEnabledAt == nil {
+		return false, ErrTwoFactorNotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		step, ok := totp.Validate(code, user.TOTPSecret, time.Now())
+		if !ok {
+			return false, nil
+		}
+		return s.userRepo.AdvanceTOTPStep(user.ID, step)
+	}
+
+	return s.recoveryCodeRepo.Consume(user.ID, hash.SHA256(normalizeRecoveryCode(code)))
+}
+
+func (s *twoFactorService) requireEnabled(userID uint64, code string) (*model.User, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	ok, err := s.VerifyCode(user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+	return user, nil
+}
+
+func (s *twoFactorService) issueRecoveryCodes(userID uint64) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		raw := strings.ToLower(recoveryEncoding.EncodeToString(b))
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, hash.SHA256(raw))
+	}
+
+	if err := s.recoveryCodeRepo.ReplaceForUser(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// accountLabel falls back to the phone for phone-only accounts
+func accountLabel(user *model.User) string {
+	if user.Email == "" && user.Phone != nil {
+		return *user.Phone
+	}
+	return user.Email
+}
+
+// normalizeRecoveryCode strips separators and case so codes can be typed loosely
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
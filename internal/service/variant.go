@@ -3,6 +3,7 @@ package service
 import (
 	"github.com/Durgarao310/zneha-backend/internal/model"
 	"github.com/Durgarao310/zneha-backend/internal/repository"
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
 )
 
 type VariantService struct {
@@ -31,16 +32,16 @@ func (s *VariantService) GetVariantsByProductID(productID uint64) ([]model.Varia
 	return s.variantRepo.GetByProductID(productID)
 }
 
-func (s *VariantService) GetVariantsByProductIDWithPagination(productID uint64, page, limit int) ([]model.Variant, int64, error) {
-	return s.variantRepo.GetByProductIDWithPagination(productID, page, limit)
+func (s *VariantService) GetVariantsByProductIDWithPagination(productID uint64, spec pagination.QuerySpec, params pagination.PaginationParams) ([]model.Variant, pagination.PageInfo, error) {
+	return s.variantRepo.GetByProductIDWithPagination(productID, spec, params)
 }
 
 func (s *VariantService) GetActiveVariantsByProductID(productID uint64) ([]model.Variant, error) {
 	return s.variantRepo.GetActiveByProductID(productID)
 }
 
-func (s *VariantService) GetActiveVariantsByProductIDWithPagination(productID uint64, page, limit int) ([]model.Variant, int64, error) {
-	return s.variantRepo.GetActiveByProductIDWithPagination(productID, page, limit)
+func (s *VariantService) GetActiveVariantsByProductIDWithPagination(productID uint64, spec pagination.QuerySpec, params pagination.PaginationParams) ([]model.Variant, pagination.PageInfo, error) {
+	return s.variantRepo.GetActiveByProductIDWithPagination(productID, spec, params)
 }
 
 func (s *VariantService) UpdateVariant(variant *model.Variant) error {
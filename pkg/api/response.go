@@ -3,6 +3,7 @@ package api
 import (
 	"time"
 
+	"github.com/Durgarao310/zneha-backend/pkg/pagination"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -20,16 +21,21 @@ type Meta struct {
 	APIVersion       string      `json:"apiVersion"`
 	ProcessingTimeMs int64       `json:"processingTimeMs"`
 	Pagination       *Pagination `json:"pagination,omitempty"`
+	Facets           any         `json:"facets,omitempty"`
 }
 
-// Pagination contains pagination information for list responses.
+// Pagination contains pagination information for list responses. Lists paged
+// by cursor carry the cursors instead of a page number, and their totals only
+// when requested.
 type Pagination struct {
-	Page       int  `json:"page"`
-	Limit      int  `json:"limit"`
-	TotalPages int  `json:"totalPages"`
-	TotalItems int  `json:"totalItems"`
-	HasNext    bool `json:"hasNext"`
-	HasPrev    bool `json:"hasPrev"`
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	TotalPages *int   `json:"totalPages,omitempty"`
+	TotalItems *int   `json:"totalItems,omitempty"`
+	HasNext    bool   `json:"hasNext"`
+	HasPrev    bool   `json:"hasPrev"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
 }
 
 // Links supports HATEOAS for resource navigation, universally applicable.
@@ -58,8 +64,8 @@ func NewPaginatedResponse[T any](c *gin.Context, data T, page, limit, totalItems
 	pagination := &Pagination{
 		Page:       page,
 		Limit:      limit,
-		TotalPages: totalPages,
-		TotalItems: totalItems,
+		TotalPages: &totalPages,
+		TotalItems: &totalItems,
 		HasNext:    page < totalPages,
 		HasPrev:    page > 1,
 	}
@@ -98,6 +104,51 @@ func SendPaginatedSuccess[T any](c *gin.Context, status int, data T, page, limit
 	c.JSON(status, NewPaginatedResponse(c, data, page, limit, totalItems))
 }
 
+// NewPageResponse creates a success response for a page loaded by page number or by cursor.
+func NewPageResponse[T any](c *gin.Context, data T, params pagination.PaginationParams, info pagination.PageInfo) APIResponse[T] {
+	if !params.UseCursor {
+		var total int
+		if info.Total != nil {
+			total = int(*info.Total)
+		}
+		return NewPaginatedResponse(c, data, params.Page, params.Limit, total)
+	}
+
+	page := &Pagination{
+		Limit:      params.Limit,
+		HasNext:    info.NextCursor != "",
+		HasPrev:    info.PrevCursor != "",
+		NextCursor: info.NextCursor,
+		PrevCursor: info.PrevCursor,
+	}
+	if info.Total != nil {
+		totalItems := int(*info.Total)
+		totalPages := (totalItems + params.Limit - 1) / params.Limit
+		page.TotalItems, page.TotalPages = &totalItems, &totalPages
+	}
+	response := NewSuccessResponse(c, data)
+	response.Meta.Pagination = page
+	return response
+}
+
+// SendPageSuccess sends a page loaded by page number or by cursor with the specified HTTP status code.
+func SendPageSuccess[T any](c *gin.Context, status int, data T, params pagination.PaginationParams, info pagination.PageInfo) {
+	if status < 200 || status > 299 {
+		status = 200
+	}
+	c.JSON(status, NewPageResponse(c, data, params, info))
+}
+
+// SendFacetedSuccess sends a page carrying facet counts in the meta.
+func SendFacetedSuccess[T any](c *gin.Context, status int, data T, params pagination.PaginationParams, info pagination.PageInfo, facets any) {
+	if status < 200 || status > 299 {
+		status = 200
+	}
+	response := NewPageResponse(c, data, params, info)
+	response.Meta.Facets = facets
+	c.JSON(status, response)
+}
+
 // Helper to get request ID from context (set by middleware).
 func getRequestID(c *gin.Context) string {
 	if id, exists := c.Get("requestID"); exists {
@@ -1,3 +1,24 @@
 package hash
 
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
 // Bcrypt hashing utilities
+
+// DefaultCost is the bcrypt cost used for password hashing
+const DefaultCost = bcrypt.DefaultCost
+
+// HashPassword returns the bcrypt hash of a plain-text password
+func HashPassword(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// CheckPassword reports whether the plain-text password matches the bcrypt hash
+func CheckPassword(hashed, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password)) == nil
+}
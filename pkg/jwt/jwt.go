@@ -1,3 +1,126 @@
 package jwt
 
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+)
+
 // JWT token utilities
+
+// Token types carried in the typ claim
+const (
+	TokenTypeAccess     = "access"      // grants access to protected API routes
+	TokenTypeMFAPending = "mfa_pending" // password verified, second factor still required
+)
+
+// ErrInvalidToken is returned when a token cannot be parsed or verified
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// Claims represents the JWT claims issued by the application
+type Claims struct {
+	UserID    uint64 `json:"uid"`
+	Email     string `json:"email"`
+	TokenType string `json:"typ"`
+	SessionID string `json:"sid,omitempty"` // refresh token family the access token belongs to
+	MFA       bool   `json:"mfa,omitempty"` // session was opened with a second factor
+	gojwt.RegisteredClaims
+}
+
+// Manager signs and verifies HS256 tokens with a shared secret
+type Manager struct {
+	secret []byte
+	issuer string
+	expiry time.Duration
+}
+
+// NewManager creates a new token manager
+func NewManager(secret, issuer string, expiry time.Duration) *Manager {
+	return &Manager{
+		secret: []byte(secret),
+		issuer: issuer,
+		expiry: expiry,
+	}
+}
+
+// GenerateAccessToken issues a signed access token for the given user session
+func (m *Manager) GenerateAccessToken(userID uint64, email, sessionID string, mfa bool) (string, time.Time, error) {
+	return m.generate(Claims{
+		UserID:    userID,
+		Email:     email,
+		TokenType: TokenTypeAccess,
+		SessionID: sessionID,
+		MFA:       mfa,
+	}, m.expiry)
+}
+
+// GenerateMFAToken issues a short-lived token proving the first factor was verified
+func (m *Manager) GenerateMFAToken(userID uint64, email string, ttl time.Duration) (string, time.Time, error) {
+	return m.generate(Claims{
+		UserID:    userID,
+		Email:     email,
+		TokenType: TokenTypeMFAPending,
+	}, ttl)
+}
+
+// ParseAccessToken verifies the token signature and expiry and returns its claims
+func (m *Manager) ParseAccessToken(tokenString string) (*Claims, error) {
+	claims, err := m.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenType != TokenTypeAccess {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// ParseMFAToken verifies an mfa pending token and returns its claims
+func (m *Manager) ParseMFAToken(tokenString string) (*Claims, error) {
+	claims, err := m.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenType != TokenTypeMFAPending {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// generate fills the registered claims and signs the token
+func (m *Manager) generate(claims Claims, ttl time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	claims.RegisteredClaims = gojwt.RegisteredClaims{
+		Issuer:    m.issuer,
+		Subject:   strconv.FormatUint(claims.UserID, 10),
+		IssuedAt:  gojwt.NewNumericDate(now),
+		NotBefore: gojwt.NewNumericDate(now),
+		ExpiresAt: gojwt.NewNumericDate(expiresAt),
+	}
+
+	token := gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(m.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// parse verifies the token and returns its claims
+func (m *Manager) parse(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := gojwt.ParseWithClaims(tokenString, claims, func(t *gojwt.Token) (interface{}, error) {
+		return m.secret, nil
+	},
+		gojwt.WithValidMethods([]string{gojwt.SigningMethodHS256.Alg()}),
+		gojwt.WithIssuer(m.issuer),
+	)
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// unsafeFileChars matches characters that should not appear in outbox file names
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// OutboxMailer writes messages as .eml files to a local directory.
+// It is meant for development and tests.
+type OutboxMailer struct {
+	dir  string
+	from string
+}
+
+// NewOutboxMailer creates a new outbox mailer
+func NewOutboxMailer(dir, from string) *OutboxMailer {
+	return &OutboxMailer{
+		dir:  dir,
+		from: from,
+	}
+}
+
+// Send writes the message to the outbox directory
+func (m *OutboxMailer) Send(msg Message) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), unsafeFileChars.ReplaceAllString(msg.To, "_"))
+	return os.WriteFile(filepath.Join(m.dir, name), render(m.from, msg), 0o644)
+}
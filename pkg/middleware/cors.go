@@ -30,6 +30,7 @@ func CORSMiddleware() gin.HandlerFunc {
 			"Accept",
 			"Authorization",
 			"Content-Type",
+			"X-API-Key",
 			"X-CSRF-Token",
 			"X-Request-ID",
 			"X-Requested-With",
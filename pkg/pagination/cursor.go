@@ -0,0 +1,77 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PageInfo describes the page a list query returned
+type PageInfo struct {
+	Total      *int64 // nil when not counted
+	NextCursor string // cursor mode only, empty on the last page
+	PrevCursor string // cursor mode only, empty on the first page
+}
+
+// Cursor points just past a row in a sorted list. It is opaque to clients:
+// the sort key values of the row, the sort they belong to and the direction.
+type Cursor struct {
+	Sort     string   `json:"s"`
+	Keys     []string `json:"k"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+// Encode returns the cursor as a URL-safe string
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor reads a cursor returned by Encode
+func DecodeCursor(raw string) (Cursor, error) {
+	var cursor Cursor
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil || json.Unmarshal(data, &cursor) != nil || len(cursor.Keys) == 0 {
+		return cursor, fmt.Errorf("%w: invalid cursor", ErrInvalidQuery)
+	}
+	return cursor, nil
+}
+
+// SortKey identifies a resolved sort, so a cursor is only used with the sort it came from
+func SortKey(orders []Order) string {
+	keys := make([]string, 0, len(orders))
+	for _, order := range orders {
+		if order.Desc {
+			keys = append(keys, "-"+order.Column)
+		} else {
+			keys = append(keys, order.Column)
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+// FormatKey writes a sort key value into a cursor
+func FormatKey(value any) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ParseKey reads a sort key value of the field's type back from a cursor
+func (f Field) ParseKey(raw string) (any, error) {
+	if f.Type == FieldString {
+		// Unlike a filter value, an empty string is a valid key
+		return raw, nil
+	}
+	return f.parse(raw)
+}
@@ -0,0 +1,120 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursors := []Cursor{
+		{Sort: "-created_at,id", Keys: []string{"2025-08-17T05:39:06.123456Z", "42"}},
+		{Sort: "price,id", Keys: []string{"1999.5", "7"}, Backward: true},
+		{Sort: "name,id", Keys: []string{`Silk "saree", 50% off`, "3"}},
+		{Sort: "name,id", Keys: []string{"", "3"}},
+	}
+	for _, cursor := range cursors {
+		decoded, err := DecodeCursor(cursor.Encode())
+		if err != nil {
+			t.Errorf("%+v: %v", cursor, err)
+			continue
+		}
+		if !reflect.DeepEqual(decoded, cursor) {
+			t.Errorf("got %+v, want %+v", decoded, cursor)
+		}
+	}
+}
+
+func TestDecodeCursorRejectsTampering(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"not base64", "not a cursor!"},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"s":"id","k":["1"]}`))},
+		{"not JSON", encode("id:1")},
+		{"truncated", Cursor{Sort: "id", Keys: []string{"1"}}.Encode()[:10]},
+		{"no keys", encode(`{"s":"id","k":[]}`)},
+		{"keys of the wrong type", encode(`{"s":"id","k":[1]}`)},
+	}
+	for _, tt := range tests {
+		if _, err := DecodeCursor(tt.raw); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("%s: err = %v, want ErrInvalidQuery", tt.name, err)
+		}
+	}
+}
+
+func TestSortKey(t *testing.T) {
+	byPrice := []Order{{Field: Field{Column: "price"}, Desc: true}, {Field: Field{Column: "id"}}}
+	if got := SortKey(byPrice); got != "-price,id" {
+		t.Errorf("SortKey = %s, want -price,id", got)
+	}
+
+	// A cursor only fits the sort it was issued for
+	others := [][]Order{
+		{{Field: Field{Column: "price"}}, {Field: Field{Column: "id"}}},
+		{{Field: Field{Column: "id"}}, {Field: Field{Column: "price"}, Desc: true}},
+		{{Field: Field{Column: "price"}, Desc: true}},
+	}
+	for _, orders := range others {
+		if SortKey(orders) == SortKey(byPrice) {
+			t.Errorf("%+v shares the sort key of %+v", orders, byPrice)
+		}
+	}
+}
+
+func TestSortKeyRoundTrip(t *testing.T) {
+	at := time.Date(2025, 8, 17, 5, 39, 6, 123456789, time.FixedZone("IST", 5*3600+1800))
+	tests := []struct {
+		field Field
+		value any
+		want  any
+	}{
+		{Field{Type: FieldTime}, at, at.UTC()},
+		{Field{Type: FieldNumber}, 1999.99, 1999.99},
+		{Field{Type: FieldNumber}, 0.1 + 0.2, 0.1 + 0.2},
+		{Field{Type: FieldNumber}, float32(2.5), 2.5},
+		{Field{Type: FieldInteger}, uint64(42), int64(42)},
+		{Field{Type: FieldString}, "Silk, cotton & more", "Silk, cotton & more"},
+		{Field{Type: FieldString}, "", ""},
+		{Field{Type: FieldBoolean}, true, true},
+	}
+	for _, tt := range tests {
+		raw := FormatKey(tt.value)
+		got, err := tt.field.ParseKey(raw)
+		if err != nil {
+			t.Errorf("%v: ParseKey(%q): %v", tt.value, raw, err)
+			continue
+		}
+		if gotTime, ok := got.(time.Time); ok {
+			if !gotTime.Equal(tt.want.(time.Time)) {
+				t.Errorf("%v: got %v, want %v", tt.value, gotTime, tt.want)
+			}
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%v: got %v (%T), want %v (%T)", tt.value, got, got, tt.want, tt.want)
+		}
+	}
+}
+
+func TestParseKeyRejectsTamperedKeys(t *testing.T) {
+	tests := []struct {
+		field Field
+		raw   string
+	}{
+		{Field{Type: FieldTime}, "yesterday"},
+		{Field{Type: FieldNumber}, "1e"},
+		{Field{Type: FieldInteger}, "1 OR 1=1"},
+		{Field{Type: FieldInteger}, ""},
+		{Field{Type: FieldBoolean}, "maybe"},
+	}
+	for _, tt := range tests {
+		if _, err := tt.field.ParseKey(tt.raw); err == nil {
+			t.Errorf("ParseKey(%q) accepted for type %d", tt.raw, tt.field.Type)
+		}
+	}
+}
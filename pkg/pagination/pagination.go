@@ -6,10 +6,14 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// PaginationParams represents pagination parameters
+// PaginationParams represents pagination parameters. A cursor parameter, empty
+// for the first page, switches from page numbers to cursor pagination.
 type PaginationParams struct {
-	Page  int
-	Limit int
+	Page      int
+	Limit     int
+	UseCursor bool
+	Cursor    string
+	WithTotal bool // always in offset mode, with total=true in cursor mode
 }
 
 // DefaultPage is the default page number
@@ -40,10 +44,10 @@ func GetPaginationParams(ctx *gin.Context) PaginationParams {
 		}
 	}
 
-	return PaginationParams{
+	return withCursor(ctx, PaginationParams{
 		Page:  page,
 		Limit: limit,
-	}
+	})
 }
 
 // GetPaginationParamsWithCustomLimits allows custom default and max limits
@@ -65,8 +69,16 @@ func GetPaginationParamsWithCustomLimits(ctx *gin.Context, defaultLimit, maxLimi
 		}
 	}
 
-	return PaginationParams{
+	return withCursor(ctx, PaginationParams{
 		Page:  page,
 		Limit: limit,
-	}
+	})
+}
+
+// withCursor reads the cursor parameters. Counting every row defeats the point
+// of cursors on large tables, so in cursor mode the total is opt-in.
+func withCursor(ctx *gin.Context, params PaginationParams) PaginationParams {
+	params.Cursor, params.UseCursor = ctx.GetQuery("cursor")
+	params.WithTotal = !params.UseCursor || ctx.Query("total") == "true"
+	return params
 }
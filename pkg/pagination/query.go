@@ -0,0 +1,251 @@
+package pagination
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrInvalidQuery is returned for malformed sort or filter parameters and for
+// fields or operators a resource does not allow
+var ErrInvalidQuery = errors.New("invalid sort or filter")
+
+// Operator compares a field with the filter value
+type Operator string
+
+const (
+	OpEq   Operator = "eq"   // equal
+	OpIn   Operator = "in"   // equal to one of a comma-separated list
+	OpGte  Operator = "gte"  // greater than or equal
+	OpLte  Operator = "lte"  // less than or equal
+	OpLike Operator = "like" // contains, case-insensitive
+)
+
+// MaxSortFields and MaxFilters bound the size of a query spec. Every value of
+// an in list counts as a filter.
+const (
+	MaxSortFields = 5
+	MaxFilters    = 20
+)
+
+// Sort orders by one field
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Filter compares one field with a raw value
+type Filter struct {
+	Field    string
+	Operator Operator
+	Value    string
+}
+
+// QuerySpec holds the sort and filter parameters of a list request:
+//
+//	sort=-createdAt,name                 descending createdAt, then ascending name
+//	filter[status]=active                equal (same as filter[status][eq]=active)
+//	filter[price][gte]=10                one of eq, in, gte, lte or like
+//	filter[status][in]=active,inactive   in takes a comma-separated list
+type QuerySpec struct {
+	Sort    []Sort
+	Filters []Filter
+}
+
+// GetQuerySpec parses the sort and filter parameters of the request
+func GetQuerySpec(ctx *gin.Context) (QuerySpec, error) {
+	return ParseQuerySpec(ctx.Request.URL.Query())
+}
+
+// ParseQuerySpec parses sort and filter parameters. Only the syntax is checked
+// here; the fields are checked against a resource's Fields when resolved.
+func ParseQuerySpec(values url.Values) (QuerySpec, error) {
+	var spec QuerySpec
+
+	if raw := strings.TrimSpace(values.Get("sort")); raw != "" {
+		seen := make(map[string]bool)
+		for _, item := range strings.Split(raw, ",") {
+			item = strings.TrimSpace(item)
+			sort := Sort{Field: strings.TrimLeft(item, "+-"), Desc: strings.HasPrefix(item, "-")}
+			if sort.Field == "" || len(item)-len(sort.Field) > 1 {
+				return spec, fmt.Errorf("%w: sort %q", ErrInvalidQuery, item)
+			}
+			if seen[sort.Field] {
+				return spec, fmt.Errorf("%w: sort field %s repeated", ErrInvalidQuery, sort.Field)
+			}
+			seen[sort.Field] = true
+			spec.Sort = append(spec.Sort, sort)
+		}
+		if len(spec.Sort) > MaxSortFields {
+			return spec, fmt.Errorf("%w: at most %d sort fields", ErrInvalidQuery, MaxSortFields)
+		}
+	}
+
+	count := 0
+	for key, raws := range values {
+		if !strings.HasPrefix(key, "filter[") {
+			continue
+		}
+		field, operator, err := parseFilterKey(key)
+		if err != nil {
+			return spec, err
+		}
+		for _, raw := range raws {
+			spec.Filters = append(spec.Filters, Filter{Field: field, Operator: operator, Value: raw})
+			count++
+			if operator == OpIn {
+				count += strings.Count(raw, ",")
+			}
+		}
+	}
+	if count > MaxFilters {
+		return spec, fmt.Errorf("%w: at most %d filters", ErrInvalidQuery, MaxFilters)
+	}
+	// Map order is random; keep the generated SQL stable
+	slices.SortFunc(spec.Filters, func(a, b Filter) int {
+		return cmp.Or(
+			strings.Compare(a.Field, b.Field),
+			strings.Compare(string(a.Operator), string(b.Operator)),
+			strings.Compare(a.Value, b.Value),
+		)
+	})
+	return spec, nil
+}
+
+// parseFilterKey splits filter[field] or filter[field][op]
+func parseFilterKey(key string) (string, Operator, error) {
+	invalid := fmt.Errorf("%w: %s", ErrInvalidQuery, key)
+	rest := strings.TrimPrefix(key, "filter[")
+	field, rest, ok := strings.Cut(rest, "]")
+	if !ok || field == "" {
+		return "", "", invalid
+	}
+	if rest == "" {
+		return field, OpEq, nil
+	}
+	if !strings.HasPrefix(rest, "[") || !strings.HasSuffix(rest, "]") {
+		return "", "", invalid
+	}
+	operator := Operator(rest[1 : len(rest)-1])
+	switch operator {
+	case OpEq, OpIn, OpGte, OpLte, OpLike:
+		return field, operator, nil
+	}
+	return "", "", fmt.Errorf("%w: unknown operator %q", ErrInvalidQuery, operator)
+}
+
+// FieldType decides how filter values are parsed and which operators apply
+type FieldType int
+
+const (
+	FieldString  FieldType = iota // eq, in, like
+	FieldInteger                  // eq, in, gte, lte
+	FieldNumber                   // eq, in, gte, lte
+	FieldBoolean                  // eq
+	FieldTime                     // eq, in, gte, lte; RFC 3339 or YYYY-MM-DD
+)
+
+// Field is a column a resource exposes to sorting and filtering
+type Field struct {
+	Column   string
+	Type     FieldType
+	Nullable bool // NULLs have no place in a keyset, so cursor pages cannot sort by it
+}
+
+// Fields whitelists a resource's fields by their API name
+type Fields map[string]Field
+
+// Condition is a filter resolved to a column and typed values
+type Condition struct {
+	Column   string
+	Operator Operator
+	Values   []any // one value, except for in
+}
+
+// Order is a sort resolved to its field
+type Order struct {
+	Field
+	Desc bool
+}
+
+// Conditions resolves the filters, rejecting fields not in the whitelist,
+// operators the field's type does not support and unparsable values
+func (f Fields) Conditions(filters []Filter) ([]Condition, error) {
+	conditions := make([]Condition, 0, len(filters))
+	for _, filter := range filters {
+		field, ok := f[filter.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot filter by %s", ErrInvalidQuery, filter.Field)
+		}
+		if !field.supports(filter.Operator) {
+			return nil, fmt.Errorf("%w: %s does not support %s", ErrInvalidQuery, filter.Field, filter.Operator)
+		}
+
+		raws := []string{filter.Value}
+		if filter.Operator == OpIn {
+			raws = strings.Split(filter.Value, ",")
+		}
+		condition := Condition{Column: field.Column, Operator: filter.Operator}
+		for _, raw := range raws {
+			value, err := field.parse(strings.TrimSpace(raw))
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid value %q for %s", ErrInvalidQuery, raw, filter.Field)
+			}
+			condition.Values = append(condition.Values, value)
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// Orders resolves the sort fields against the whitelist
+func (f Fields) Orders(sorts []Sort) ([]Order, error) {
+	orders := make([]Order, 0, len(sorts))
+	for _, sort := range sorts {
+		field, ok := f[sort.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot sort by %s", ErrInvalidQuery, sort.Field)
+		}
+		orders = append(orders, Order{Field: field, Desc: sort.Desc})
+	}
+	return orders, nil
+}
+
+func (f Field) supports(operator Operator) bool {
+	switch f.Type {
+	case FieldString:
+		return operator == OpEq || operator == OpIn || operator == OpLike
+	case FieldBoolean:
+		return operator == OpEq
+	default:
+		return operator != OpLike
+	}
+}
+
+func (f Field) parse(raw string) (any, error) {
+	switch f.Type {
+	case FieldInteger:
+		return strconv.ParseInt(raw, 10, 64)
+	case FieldNumber:
+		return strconv.ParseFloat(raw, 64)
+	case FieldBoolean:
+		return strconv.ParseBool(raw)
+	case FieldTime:
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t, nil
+		}
+		return time.Parse(time.DateOnly, raw)
+	default:
+		if raw == "" {
+			return nil, errors.New("empty value")
+		}
+		return raw, nil
+	}
+}
@@ -0,0 +1,229 @@
+package pagination
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseQuerySpecSort(t *testing.T) {
+	tests := []struct {
+		raw   string
+		want  []Sort
+		valid bool
+	}{
+		{"", nil, true},
+		{"name", []Sort{{Field: "name"}}, true},
+		{"-createdAt, +name", []Sort{{Field: "createdAt", Desc: true}, {Field: "name"}}, true},
+		{"--name", nil, false},
+		{"+-name", nil, false},
+		{"-", nil, false},
+		{"name,,price", nil, false},
+		{"name,-name", nil, false},
+		{"a,b,c,d,e", []Sort{{Field: "a"}, {Field: "b"}, {Field: "c"}, {Field: "d"}, {Field: "e"}}, true},
+		{"a,b,c,d,e,f", nil, false},
+	}
+	for _, tt := range tests {
+		spec, err := ParseQuerySpec(url.Values{"sort": {tt.raw}})
+		if !tt.valid {
+			if !errors.Is(err, ErrInvalidQuery) {
+				t.Errorf("sort=%q: err = %v, want ErrInvalidQuery", tt.raw, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("sort=%q: %v", tt.raw, err)
+			continue
+		}
+		if !reflect.DeepEqual(spec.Sort, tt.want) {
+			t.Errorf("sort=%q: got %+v, want %+v", tt.raw, spec.Sort, tt.want)
+		}
+	}
+}
+
+func TestParseQuerySpecFilterKeys(t *testing.T) {
+	tests := []struct {
+		key   string
+		want  Filter
+		valid bool
+	}{
+		{"filter[status]", Filter{Field: "status", Operator: OpEq, Value: "v"}, true},
+		{"filter[price][gte]", Filter{Field: "price", Operator: OpGte, Value: "v"}, true},
+		{"filter[price][lte]", Filter{Field: "price", Operator: OpLte, Value: "v"}, true},
+		{"filter[name][like]", Filter{Field: "name", Operator: OpLike, Value: "v"}, true},
+		{"filter[id][in]", Filter{Field: "id", Operator: OpIn, Value: "v"}, true},
+		{"filter[]", Filter{}, false},
+		{"filter[status", Filter{}, false},
+		{"filter[status]x", Filter{}, false},
+		{"filter[status]]", Filter{}, false},
+		{"filter[status][]", Filter{}, false},
+		{"filter[status][gte", Filter{}, false},
+		{"filter[status][gt]", Filter{}, false},
+		{"filter[status][eq]]", Filter{}, false},
+		{"filter[status][eq][in]", Filter{}, false},
+		{"filter[status][EQ]", Filter{}, false},
+	}
+	for _, tt := range tests {
+		spec, err := ParseQuerySpec(url.Values{tt.key: {"v"}})
+		if !tt.valid {
+			if !errors.Is(err, ErrInvalidQuery) {
+				t.Errorf("%s: err = %v, want ErrInvalidQuery", tt.key, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.key, err)
+			continue
+		}
+		if len(spec.Filters) != 1 || spec.Filters[0] != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.key, spec.Filters, tt.want)
+		}
+	}
+}
+
+func TestParseQuerySpecIgnoresOtherParameters(t *testing.T) {
+	spec, err := ParseQuerySpec(url.Values{"page": {"2"}, "filters": {"x"}, "filterx": {"y"}, "attr[fabric]": {"silk"}})
+	if err != nil || len(spec.Filters) != 0 || len(spec.Sort) != 0 {
+		t.Errorf("got %+v, %v; want an empty spec", spec, err)
+	}
+}
+
+func TestParseQuerySpecFilterLimit(t *testing.T) {
+	list := func(n int) string {
+		values := make([]string, n)
+		for i := range values {
+			values[i] = "1"
+		}
+		return strings.Join(values, ",")
+	}
+	repeated := func(n int) url.Values {
+		values := url.Values{}
+		for range n {
+			values.Add("filter[id]", "1")
+		}
+		return values
+	}
+
+	tests := []struct {
+		name   string
+		values url.Values
+		valid  bool
+	}{
+		{"filters at the limit", repeated(MaxFilters), true},
+		{"filters over the limit", repeated(MaxFilters + 1), false},
+		{"in list at the limit", url.Values{"filter[id][in]": {list(MaxFilters)}}, true},
+		{"in list over the limit", url.Values{"filter[id][in]": {list(MaxFilters + 1)}}, false},
+		{"in list and filters over the limit", url.Values{"filter[id][in]": {list(MaxFilters)}, "filter[status]": {"active"}}, false},
+		{"commas outside in lists", url.Values{"filter[name][like]": {list(MaxFilters + 1)}}, true},
+	}
+	for _, tt := range tests {
+		_, err := ParseQuerySpec(tt.values)
+		if tt.valid && err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("%s: err = %v, want ErrInvalidQuery", tt.name, err)
+		}
+	}
+}
+
+func TestParseQuerySpecFilterOrderIsStable(t *testing.T) {
+	values := url.Values{
+		"filter[status]":      {"inactive", "active"},
+		"filter[price][lte]":  {"100"},
+		"filter[price][gte]":  {"10"},
+		"filter[name][like]":  {"silk"},
+		"filter[createdAt]":   {"2024-01-01"},
+		"filter[brandId][in]": {"3,4"},
+	}
+	want := []Filter{
+		{Field: "brandId", Operator: OpIn, Value: "3,4"},
+		{Field: "createdAt", Operator: OpEq, Value: "2024-01-01"},
+		{Field: "name", Operator: OpLike, Value: "silk"},
+		{Field: "price", Operator: OpGte, Value: "10"},
+		{Field: "price", Operator: OpLte, Value: "100"},
+		{Field: "status", Operator: OpEq, Value: "active"},
+		{Field: "status", Operator: OpEq, Value: "inactive"},
+	}
+	for range 10 {
+		spec, err := ParseQuerySpec(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(spec.Filters, want) {
+			t.Fatalf("got %+v, want %+v", spec.Filters, want)
+		}
+	}
+}
+
+var testFields = Fields{
+	"id":        {Column: "id", Type: FieldInteger},
+	"name":      {Column: "name", Type: FieldString},
+	"price":     {Column: "price", Type: FieldNumber},
+	"isActive":  {Column: "is_active", Type: FieldBoolean},
+	"createdAt": {Column: "created_at", Type: FieldTime},
+}
+
+func TestFieldsConditions(t *testing.T) {
+	tests := []struct {
+		filter Filter
+		want   Condition
+		valid  bool
+	}{
+		{Filter{"id", OpEq, "7"}, Condition{"id", OpEq, []any{int64(7)}}, true},
+		{Filter{"id", OpIn, "1, 2,3"}, Condition{"id", OpIn, []any{int64(1), int64(2), int64(3)}}, true},
+		{Filter{"price", OpGte, "9.5"}, Condition{"price", OpGte, []any{9.5}}, true},
+		{Filter{"isActive", OpEq, "true"}, Condition{"is_active", OpEq, []any{true}}, true},
+		{Filter{"name", OpIn, "silk,cotton"}, Condition{"name", OpIn, []any{"silk", "cotton"}}, true},
+		{Filter{"createdAt", OpGte, "2024-03-01"}, Condition{"created_at", OpGte, []any{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}}, true},
+		{Filter{"createdAt", OpLte, "2024-03-01T10:00:00Z"}, Condition{"created_at", OpLte, []any{time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)}}, true},
+		// A like value is kept as given; the wildcards in it are escaped when the query is built
+		{Filter{"name", OpLike, `50%_off\`}, Condition{"name", OpLike, []any{`50%_off\`}}, true},
+
+		{Filter{"password", OpEq, "x"}, Condition{}, false},
+		{Filter{"is_active", OpEq, "true"}, Condition{}, false}, // column names are not API names
+		{Filter{"id", OpLike, "1"}, Condition{}, false},
+		{Filter{"name", OpGte, "a"}, Condition{}, false},
+		{Filter{"isActive", OpIn, "true,false"}, Condition{}, false},
+		{Filter{"id", OpEq, "1 OR 1=1"}, Condition{}, false},
+		{Filter{"id", OpIn, "1,,2"}, Condition{}, false},
+		{Filter{"price", OpLte, "cheap"}, Condition{}, false},
+		{Filter{"isActive", OpEq, "maybe"}, Condition{}, false},
+		{Filter{"createdAt", OpGte, "yesterday"}, Condition{}, false},
+		{Filter{"name", OpEq, ""}, Condition{}, false},
+	}
+	for _, tt := range tests {
+		conditions, err := testFields.Conditions([]Filter{tt.filter})
+		if !tt.valid {
+			if !errors.Is(err, ErrInvalidQuery) {
+				t.Errorf("%+v: err = %v, want ErrInvalidQuery", tt.filter, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%+v: %v", tt.filter, err)
+			continue
+		}
+		if len(conditions) != 1 || !reflect.DeepEqual(conditions[0], tt.want) {
+			t.Errorf("%+v: got %+v, want %+v", tt.filter, conditions, tt.want)
+		}
+	}
+}
+
+func TestFieldsOrders(t *testing.T) {
+	orders, err := testFields.Orders([]Sort{{Field: "price", Desc: true}, {Field: "id"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Order{{Field: testFields["price"], Desc: true}, {Field: testFields["id"]}}
+	if !reflect.DeepEqual(orders, want) {
+		t.Errorf("got %+v, want %+v", orders, want)
+	}
+
+	if _, err := testFields.Orders([]Sort{{Field: "password"}}); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("unknown sort field: err = %v, want ErrInvalidQuery", err)
+	}
+}
@@ -0,0 +1,127 @@
+package seo
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// schema.org and Open Graph helpers
+
+// schema.org availability values
+const (
+	InStock    = "https://schema.org/InStock"
+	OutOfStock = "https://schema.org/OutOfStock"
+)
+
+// Product is a schema.org Product. Offers holds an Offer or an AggregateOffer.
+type Product struct {
+	Context     string   `json:"@context"`
+	Type        string   `json:"@type"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Image       []string `json:"image,omitempty"`
+	SKU         string   `json:"sku,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Brand       *Brand   `json:"brand,omitempty"`
+	Offers      any      `json:"offers,omitempty"`
+}
+
+// Brand is a schema.org Brand
+type Brand struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// Offer is a schema.org Offer for a single purchasable variant
+type Offer struct {
+	Type          string `json:"@type"`
+	SKU           string `json:"sku,omitempty"`
+	Price         string `json:"price"`
+	PriceCurrency string `json:"priceCurrency"`
+	Availability  string `json:"availability"`
+	URL           string `json:"url,omitempty"`
+}
+
+// AggregateOffer summarizes the offers of a product sold in several variants
+type AggregateOffer struct {
+	Type          string  `json:"@type"`
+	LowPrice      string  `json:"lowPrice"`
+	HighPrice     string  `json:"highPrice"`
+	PriceCurrency string  `json:"priceCurrency"`
+	OfferCount    int     `json:"offerCount"`
+	Availability  string  `json:"availability"`
+	Offers        []Offer `json:"offers"`
+}
+
+// MetaTag is an Open Graph tag, rendered as <meta property="..." content="...">
+type MetaTag struct {
+	Property string `json:"property"`
+	Content  string `json:"content"`
+}
+
+// NewProduct starts a Product with its JSON-LD context and type filled in
+func NewProduct(name string) Product {
+	return Product{Context: "https://schema.org", Type: "Product", Name: name}
+}
+
+// NewOffer builds an Offer; stock above zero makes it available
+func NewOffer(sku string, price float64, currency string, stock int, url string) Offer {
+	return Offer{
+		Type:          "Offer",
+		SKU:           sku,
+		Price:         FormatPrice(price),
+		PriceCurrency: currency,
+		Availability:  Availability(stock > 0),
+		URL:           url,
+	}
+}
+
+// NewAggregateOffer summarizes offers sharing one currency. It is available when any offer is.
+func NewAggregateOffer(offers []Offer, low, high float64, currency string) AggregateOffer {
+	availability := OutOfStock
+	for _, offer := range offers {
+		if offer.Availability == InStock {
+			availability = InStock
+			break
+		}
+	}
+	return AggregateOffer{
+		Type:          "AggregateOffer",
+		LowPrice:      FormatPrice(low),
+		HighPrice:     FormatPrice(high),
+		PriceCurrency: currency,
+		OfferCount:    len(offers),
+		Availability:  availability,
+		Offers:        offers,
+	}
+}
+
+// Availability maps a stock state to its schema.org value
+func Availability(inStock bool) string {
+	if inStock {
+		return InStock
+	}
+	return OutOfStock
+}
+
+// FormatPrice renders a price with two decimals as schema.org expects, e.g. 1499.00
+func FormatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', 2, 64)
+}
+
+// Truncate shortens text to at most max characters on a word boundary, adding an
+// ellipsis when it cuts. Used for descriptions that must fit search snippets.
+func Truncate(text string, max int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	runes := []rune(text)[:max-1]
+	cut := string(runes)
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:-") + "…"
+}
@@ -0,0 +1,96 @@
+package slug
+
+import "strings"
+
+// Devanagari to Latin transliteration, simplified for readable slugs rather than
+// scholarly accuracy: long vowels are written short and a word's final inherent
+// vowel is dropped, so कुर्ता becomes kurta and साड़ी becomes sari.
+
+const (
+	virama = '्'
+	nukta  = '़'
+)
+
+var devanagariConsonants = map[rune]string{
+	'क': "k", 'ख': "kh", 'ग': "g", 'घ': "gh", 'ङ': "n",
+	'च': "ch", 'छ': "chh", 'ज': "j", 'झ': "jh", 'ञ': "n",
+	'ट': "t", 'ठ': "th", 'ड': "d", 'ढ': "dh", 'ण': "n",
+	'त': "t", 'थ': "th", 'द': "d", 'ध': "dh", 'न': "n",
+	'प': "p", 'फ': "ph", 'ब': "b", 'भ': "bh", 'म': "m",
+	'य': "y", 'र': "r", 'ल': "l", 'व': "v", 'ळ': "l",
+	'श': "sh", 'ष': "sh", 'स': "s", 'ह': "h",
+	'क़': "q", 'ख़': "kh", 'ग़': "g", 'ज़': "z",
+	'ड़': "r", 'ढ़': "rh", 'फ़': "f", 'य़': "y",
+}
+
+// consonants written with a nukta sign instead of their precomposed form
+var nuktaConsonants = map[rune]string{
+	'क': "q", 'ख': "kh", 'ग': "g", 'ज': "z", 'ड': "r", 'ढ': "rh", 'फ': "f", 'य': "y",
+}
+
+var devanagariVowels = map[rune]string{
+	'अ': "a", 'आ': "a", 'इ': "i", 'ई': "i", 'उ': "u", 'ऊ': "u",
+	'ऋ': "ri", 'ए': "e", 'ऐ': "ai", 'ओ': "o", 'औ': "au",
+}
+
+var devanagariVowelSigns = map[rune]string{
+	'ा': "a", 'ि': "i", 'ी': "i", 'ु': "u", 'ू': "u",
+	'ृ': "ri", 'े': "e", 'ै': "ai", 'ो': "o", 'ौ': "au",
+}
+
+var devanagariModifiers = map[rune]string{
+	'ं': "n", 'ँ': "n", 'ः': "h",
+}
+
+// transliterateDevanagari replaces Devanagari letters and digits with Latin ones
+// and leaves every other character untouched
+func transliterateDevanagari(text string) string {
+	runes := []rune(text)
+	var b strings.Builder
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if consonant, ok := devanagariConsonants[r]; ok {
+			if i+1 < len(runes) && runes[i+1] == nukta {
+				if replaced, ok := nuktaConsonants[r]; ok {
+					consonant = replaced
+				}
+				i++
+			}
+			b.WriteString(consonant)
+			if i+1 < len(runes) && carriesInherentVowel(runes[i+1]) {
+				b.WriteByte('a')
+			}
+			continue
+		}
+
+		switch {
+		case devanagariVowels[r] != "":
+			b.WriteString(devanagariVowels[r])
+		case devanagariVowelSigns[r] != "":
+			b.WriteString(devanagariVowelSigns[r])
+		case devanagariModifiers[r] != "":
+			b.WriteString(devanagariModifiers[r])
+		case r >= '०' && r <= '९':
+			b.WriteRune('0' + (r - '०'))
+		case r == virama, r == nukta:
+			// no sound of its own
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// carriesInherentVowel reports whether a consonant followed by next is
+// pronounced with its inherent a. It is silent before a vowel sign or virama
+// and at the end of a word.
+func carriesInherentVowel(next rune) bool {
+	if _, ok := devanagariConsonants[next]; ok {
+		return true
+	}
+	if _, ok := devanagariVowels[next]; ok {
+		return true
+	}
+	_, ok := devanagariModifiers[next]
+	return ok
+}
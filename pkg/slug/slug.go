@@ -0,0 +1,67 @@
+package slug
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// URL slug utilities
+
+// MaxLength caps generated slugs so a numeric suffix still fits the 255 character column
+const MaxLength = 200
+
+// letters that do not decompose into an ASCII base letter plus accents
+var transliterations = strings.NewReplacer(
+	"ß", "ss", "æ", "ae", "Æ", "ae", "œ", "oe", "Œ", "oe",
+	"ø", "o", "Ø", "o", "ł", "l", "Ł", "l", "đ", "d", "Đ", "d",
+	"ð", "d", "Ð", "d", "þ", "th", "Þ", "th", "ı", "i",
+	"&", " and ", "@", " at ", "₹", " rs ",
+	"'", "", "’", "",
+)
+
+var validSlug = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Make turns text into a lowercase, hyphen separated slug. Accented Latin letters
+// are folded to ASCII and Devanagari is transliterated; other characters without
+// an ASCII form are dropped, so the result may be empty.
+func Make(text string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range norm.NFKD.String(transliterations.Replace(transliterateDevanagari(text))) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// accent split off by NFKD
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			hyphen = false
+		case r >= 'A' && r <= 'Z':
+			b.WriteRune(unicode.ToLower(r))
+			hyphen = false
+		default:
+			if b.Len() > 0 && !hyphen {
+				b.WriteByte('-')
+				hyphen = true
+			}
+		}
+	}
+
+	s := strings.TrimSuffix(b.String(), "-")
+	if len(s) > MaxLength {
+		s = strings.TrimRight(s[:MaxLength], "-")
+	}
+	return s
+}
+
+// WithSuffix appends a numeric suffix used to make a slug unique, e.g. red-shirt-2
+func WithSuffix(base string, n int) string {
+	return base + "-" + strconv.Itoa(n)
+}
+
+// Valid reports whether s is a well-formed slug
+func Valid(s string) bool {
+	return len(s) <= MaxLength && validSlug.MatchString(s)
+}
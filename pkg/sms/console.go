@@ -0,0 +1,25 @@
+package sms
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// ConsoleSender prints messages instead of sending them.
+// It is meant for local development.
+type ConsoleSender struct {
+	out io.Writer
+}
+
+// NewConsoleSender creates a sender that writes to stdout
+func NewConsoleSender() *ConsoleSender {
+	return &ConsoleSender{out: os.Stdout}
+}
+
+// Send prints the message
+func (s *ConsoleSender) Send(to, message string) error {
+	_, err := fmt.Fprintf(s.out, "SMS [%s] to %s: %s\n", time.Now().Format(time.RFC3339), to, message)
+	return err
+}
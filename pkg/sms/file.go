@@ -0,0 +1,33 @@
+package sms
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// unsafeFileChars matches characters that should not appear in outbox file names
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// FileSender writes each message to a .txt file in a local directory.
+// It is meant for development and tests.
+type FileSender struct {
+	dir string
+}
+
+// NewFileSender creates a new file sender
+func NewFileSender(dir string) *FileSender {
+	return &FileSender{dir: dir}
+}
+
+// Send writes the message to the outbox directory
+func (s *FileSender) Send(to, message string) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d-%s.txt", time.Now().UnixNano(), unsafeFileChars.ReplaceAllString(to, "_"))
+	body := fmt.Sprintf("To: %s\nDate: %s\n\n%s\n", to, time.Now().Format(time.RFC1123Z), message)
+	return os.WriteFile(filepath.Join(s.dir, name), []byte(body), 0o644)
+}
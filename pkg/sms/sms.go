@@ -0,0 +1,6 @@
+package sms
+
+// SMSSender delivers text messages to phone numbers in E.164 format
+type SMSSender interface {
+	Send(to, message string) error
+}
@@ -0,0 +1,88 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 seed of RFC 6238 Appendix B, "12345678901234567890" in base32
+var rfcSecret = encoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateRFC6238Vectors(t *testing.T) {
+	// Appendix B lists 8-digit codes; a 6-digit code is their last six digits
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},          // 94287082
+		{1111111109, "081804"},  // 07081804
+		{1111111111, "050471"},  // 14050471
+		{1234567890, "005924"},  // 89005924
+		{2000000000, "279037"},  // 69279037
+		{20000000000, "353130"}, // 65353130
+	}
+	for _, tt := range tests {
+		code, err := Generate(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("Generate(%d): %v", tt.unix, err)
+		}
+		if code != tt.code {
+			t.Errorf("Generate(%d) = %s, want %s", tt.unix, code, tt.code)
+		}
+	}
+}
+
+func TestGenerateAcceptsLowercaseSecret(t *testing.T) {
+	code, err := Generate("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", time.Unix(59, 0))
+	if err != nil || code != "287082" {
+		t.Errorf("Generate = %q, %v; want 287082", code, err)
+	}
+}
+
+func TestValidateSkewWindow(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := counter(now)
+
+	tests := []struct {
+		name   string
+		offset int64 // periods between the code and now
+		valid  bool
+	}{
+		{"current period", 0, true},
+		{"previous period", -1, true},
+		{"next period", 1, true},
+		{"two periods old", -2, false},
+		{"two periods ahead", 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := generateAt(rfcSecret, current+tt.offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			step, ok := Validate(code, rfcSecret, now)
+			if ok != tt.valid {
+				t.Fatalf("Validate = %v, want %v", ok, tt.valid)
+			}
+			// The matched step is what callers store to reject replays
+			if ok && step != current+tt.offset {
+				t.Errorf("step = %d, want %d", step, current+tt.offset)
+			}
+		})
+	}
+}
+
+func TestValidateRejectsMalformedCodes(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	for _, code := range []string{"", "05924", "0005924", "abcdef"} {
+		if _, ok := Validate(code, rfcSecret, now); ok {
+			t.Errorf("Validate(%q) accepted", code)
+		}
+	}
+	if _, ok := Validate(" 005924 ", rfcSecret, now); !ok {
+		t.Error("Validate rejected a code with surrounding spaces")
+	}
+	if _, ok := Validate("005924", "not base32!", now); ok {
+		t.Error("Validate accepted a code for an invalid secret")
+	}
+}
@@ -0,0 +1,44 @@
+package validator
+
+import "strings"
+
+// IndianCountryCode is the E.164 prefix of Indian phone numbers
+const IndianCountryCode = "+91"
+
+// NormalizeIndianPhone converts an Indian mobile number to E.164 (+91XXXXXXXXXX).
+// It accepts spaces, dashes and a +91, 91 or 0 prefix, and reports false for
+// anything that is not a 10-digit mobile number starting with 6-9.
+func NormalizeIndianPhone(phone string) (string, bool) {
+	phone = strings.TrimSpace(phone)
+	international := strings.HasPrefix(phone, "+")
+	digits := make([]byte, 0, len(phone))
+	for i := 0; i < len(phone); i++ {
+		c := phone[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case c == ' ' || c == '-' || c == '(' || c == ')':
+			// formatting characters are ignored
+		case c == '+' && i == 0:
+			// leading plus of an international prefix
+		default:
+			return "", false
+		}
+	}
+
+	number := string(digits)
+	if international && !strings.HasPrefix(number, "91") {
+		return "", false
+	}
+	switch {
+	case len(number) == 12 && strings.HasPrefix(number, "91"):
+		number = number[2:]
+	case len(number) == 11 && strings.HasPrefix(number, "0"):
+		number = number[1:]
+	}
+
+	if len(number) != 10 || number[0] < '6' {
+		return "", false
+	}
+	return IndianCountryCode + number, true
+}
@@ -0,0 +1,15 @@
+package validator
+
+// IsIndianPincode reports whether s is a six-digit Indian postal code.
+// Pincodes never start with 0; the first digit is the postal region.
+func IsIndianPincode(s string) bool {
+	if len(s) != 6 || s[0] < '1' || s[0] > '9' {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -4,9 +4,30 @@ import (
 	"fmt"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
+// RegisterCustomValidators adds the project's validation tags to gin's binding engine:
+// in_pincode for Indian postal codes and in_phone for Indian mobile numbers.
+func RegisterCustomValidators() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return fmt.Errorf("unexpected validator engine %T", binding.Validator.Engine())
+	}
+
+	if err := v.RegisterValidation("in_pincode", func(fl validator.FieldLevel) bool {
+		return IsIndianPincode(fl.Field().String())
+	}); err != nil {
+		return err
+	}
+
+	return v.RegisterValidation("in_phone", func(fl validator.FieldLevel) bool {
+		_, ok := NormalizeIndianPhone(fl.Field().String())
+		return ok
+	})
+}
+
 // HandleValidationErrors processes validation errors and returns structured field errors
 func HandleValidationErrors(c *gin.Context, err error) bool {
 	return false
@@ -33,6 +54,10 @@ func GetValidationErrorMessage(fe validator.FieldError) string {
 		return "Must be a number"
 	case "alpha":
 		return "Must contain only letters"
+	case "in_pincode":
+		return "Must be a valid 6-digit Indian pincode"
+	case "in_phone":
+		return "Must be a valid Indian mobile number"
 	default:
 		return "Invalid value for field " + fe.Field()
 	}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/Durgarao310/zneha-backend/internal/database"
 	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/pkg/slug"
+	"gorm.io/gorm"
 )
 
 func main() {
@@ -15,25 +17,206 @@ func main() {
 	if err := db.AutoMigrate(&model.Category{}); err != nil {
 		log.Fatalf("Category migration failed: %v", err)
 	}
+	// Rebuild materialized paths and depths from parent links; safe to re-run
+	backfill := `WITH RECURSIVE tree AS (
+		SELECT id, '/' || id || '/' AS path, 0 AS depth FROM category WHERE parent_id IS NULL
+		UNION ALL
+		SELECT c.id, t.path || c.id || '/', t.depth + 1 FROM category c JOIN tree t ON c.parent_id = t.id
+	)
+	UPDATE category SET path = tree.path, depth = tree.depth
+	FROM tree WHERE category.id = tree.id AND (category.path <> tree.path OR category.depth <> tree.depth)`
+	if err := db.Exec(backfill).Error; err != nil {
+		log.Fatalf("Category path backfill failed: %v", err)
+	}
+	// text_pattern_ops lets prefix LIKE queries on the path use the index
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_category_path ON category (path text_pattern_ops)").Error; err != nil {
+		log.Fatalf("Category path index failed: %v", err)
+	}
 	log.Println("✅ Category table migrated")
 
-	// Products next (no dependencies on other new models)
+	// Brands before the products that reference them
+	if err := db.AutoMigrate(&model.Brand{}); err != nil {
+		log.Fatalf("Brand migration failed: %v", err)
+	}
+	log.Println("✅ Brand table migrated")
+
+	// Products next (depend on brands)
 	if err := db.AutoMigrate(&model.Product{}); err != nil {
 		log.Fatalf("Product migration failed: %v", err)
 	}
 	log.Println("✅ Product table migrated")
 
+	// Product-category assignments (depends on products and categories)
+	if err := db.AutoMigrate(&model.ProductCategory{}); err != nil {
+		log.Fatalf("ProductCategory migration failed: %v", err)
+	}
+	log.Println("✅ ProductCategory table migrated")
+
+	// Slug history, then slugs for rows created before slugs existed
+	if err := db.AutoMigrate(&model.SlugRedirect{}); err != nil {
+		log.Fatalf("SlugRedirect migration failed: %v", err)
+	}
+	for _, entityType := range []string{model.SlugEntityCategory, model.SlugEntityProduct} {
+		if err := backfillSlugs(db, entityType); err != nil {
+			log.Fatalf("Slug backfill for %s failed: %v", entityType, err)
+		}
+	}
+	log.Println("✅ SlugRedirect table migrated and slugs backfilled")
+
 	// Variants (depends on products)
 	if err := db.AutoMigrate(&model.Variant{}); err != nil {
 		log.Fatalf("Variant migration failed: %v", err)
 	}
 	log.Println("✅ Variant table migrated")
 
+	// Full-text search over products, their variants and categories (depends on all three)
+	if err := setupProductSearch(db); err != nil {
+		log.Fatalf("Product search setup failed: %v", err)
+	}
+	log.Println("✅ Product search vector, triggers and index installed")
+
+	// Trigram indexes for search suggestions (products, categories and brands)
+	if err := setupSearchSuggest(db); err != nil {
+		log.Fatalf("Search suggest setup failed: %v", err)
+	}
+	log.Println("✅ pg_trgm and search suggest indexes installed")
+
+	// Option types and values, and the option values each variant has (depends on products and variants)
+	if err := db.AutoMigrate(&model.OptionType{}, &model.OptionValue{}, &model.VariantOptionValue{}); err != nil {
+		log.Fatalf("Option migration failed: %v", err)
+	}
+	log.Println("✅ OptionType, OptionValue and VariantOptionValue tables migrated")
+
+	// Attribute definitions, their category assignments and product values (depends on categories and products)
+	if err := db.AutoMigrate(&model.Attribute{}, &model.AttributeOption{}, &model.CategoryAttribute{}, &model.ProductAttributeValue{}); err != nil {
+		log.Fatalf("Attribute migration failed: %v", err)
+	}
+	log.Println("✅ Attribute, AttributeOption, CategoryAttribute and ProductAttributeValue tables migrated")
+
 	// Media last (depends on both products and variants)
 	if err := db.AutoMigrate(&model.Media{}); err != nil {
 		log.Fatalf("Media migration failed: %v", err)
 	}
 	log.Println("✅ Media table migrated")
 
+	// Metafield definitions and values (values reference products, variants, categories or media by owner type)
+	if err := db.AutoMigrate(&model.MetafieldDefinition{}, &model.Metafield{}); err != nil {
+		log.Fatalf("Metafield migration failed: %v", err)
+	}
+	log.Println("✅ MetafieldDefinition and Metafield tables migrated")
+
+	// Permissions and roles (roles depend on permissions)
+	if err := db.AutoMigrate(&model.Permission{}, &model.Role{}); err != nil {
+		log.Fatalf("Role migration failed: %v", err)
+	}
+	log.Println("✅ Permission and Role tables migrated")
+
+	// Make sure every permission known to the application exists
+	for name, description := range model.AllPermissions {
+		permission := model.Permission{Name: name}
+		if err := db.Where(permission).Assign(model.Permission{Description: description}).FirstOrCreate(&permission).Error; err != nil {
+			log.Fatalf("Permission sync failed: %v", err)
+		}
+	}
+	log.Println("✅ Permissions synced")
+
+	// Users (depends on roles)
+	if err := db.AutoMigrate(&model.User{}); err != nil {
+		log.Fatalf("User migration failed: %v", err)
+	}
+	// Phone-only accounts have no email; the old full unique index is replaced by a partial one
+	if db.Migrator().HasIndex(&model.User{}, "idx_user_email") {
+		if err := db.Migrator().DropIndex(&model.User{}, "idx_user_email"); err != nil {
+			log.Fatalf("Dropping old user email index failed: %v", err)
+		}
+	}
+	log.Println("✅ User table migrated")
+
+	// Refresh tokens (depends on users)
+	if err := db.AutoMigrate(&model.RefreshToken{}); err != nil {
+		log.Fatalf("RefreshToken migration failed: %v", err)
+	}
+	log.Println("✅ RefreshToken table migrated")
+
+	// API keys (optionally reference the creating user)
+	if err := db.AutoMigrate(&model.APIKey{}); err != nil {
+		log.Fatalf("APIKey migration failed: %v", err)
+	}
+	log.Println("✅ APIKey table migrated")
+
+	// Password reset and email verification tokens (depends on users)
+	if err := db.AutoMigrate(&model.UserToken{}); err != nil {
+		log.Fatalf("UserToken migration failed: %v", err)
+	}
+	log.Println("✅ UserToken table migrated")
+
+	// Two-factor recovery codes (depends on users)
+	if err := db.AutoMigrate(&model.RecoveryCode{}); err != nil {
+		log.Fatalf("RecoveryCode migration failed: %v", err)
+	}
+	log.Println("✅ RecoveryCode table migrated")
+
+	// Brute-force protection counters and lockout audit log
+	if err := db.AutoMigrate(&model.LoginThrottle{}, &model.LockoutEvent{}); err != nil {
+		log.Fatalf("LoginThrottle migration failed: %v", err)
+	}
+	log.Println("✅ LoginThrottle and LockoutEvent tables migrated")
+
+	// SMS login codes
+	if err := db.AutoMigrate(&model.PhoneOTP{}); err != nil {
+		log.Fatalf("PhoneOTP migration failed: %v", err)
+	}
+	log.Println("✅ PhoneOTP table migrated")
+
+	// Address book (depends on users)
+	if err := db.AutoMigrate(&model.Address{}); err != nil {
+		log.Fatalf("Address migration failed: %v", err)
+	}
+	log.Println("✅ Address table migrated")
+
 	log.Println("🎉 All migrations completed successfully!")
 }
+
+// backfillSlugs generates a unique slug from the name of every row that has none
+func backfillSlugs(db *gorm.DB, entityType string) error {
+	var rows []struct {
+		ID   uint64
+		Name string
+	}
+	if err := db.Table(entityType).Select("id, name").Where("slug = ''").Order("id").Find(&rows).Error; err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var used []string
+	if err := db.Table(entityType).Where("slug <> ''").Pluck("slug", &used).Error; err != nil {
+		return err
+	}
+	var redirected []string
+	if err := db.Model(&model.SlugRedirect{}).Where("entity_type = ?", entityType).Pluck("slug", &redirected).Error; err != nil {
+		return err
+	}
+	taken := make(map[string]bool, len(used)+len(redirected))
+	for _, s := range append(used, redirected...) {
+		taken[s] = true
+	}
+
+	for _, row := range rows {
+		base := slug.Make(row.Name)
+		if base == "" {
+			base = entityType
+		}
+		candidate := base
+		for n := 2; taken[candidate]; n++ {
+			candidate = slug.WithSuffix(base, n)
+		}
+		taken[candidate] = true
+
+		if err := db.Table(entityType).Where("id = ?", row.ID).Update("slug", candidate).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
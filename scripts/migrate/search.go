@@ -0,0 +1,104 @@
+package main
+
+import "gorm.io/gorm"
+
+// productSearchSQL keeps product.search_vector current. The vector weighs the
+// name and variant SKUs highest (A), then the short description and category
+// names (B), then the description (C). Triggers on every source table refresh it,
+// and a GIN index serves the @@ matches of GET /products/search.
+var productSearchSQL = []string{
+	`ALTER TABLE product ADD COLUMN IF NOT EXISTS search_vector tsvector NOT NULL DEFAULT ''::tsvector`,
+
+	`CREATE OR REPLACE FUNCTION product_search_document(p_id bigint, p_name text, p_short_description text, p_description text)
+	RETURNS tsvector LANGUAGE sql STABLE AS $$
+		SELECT setweight(to_tsvector('english', coalesce(p_name, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce((SELECT string_agg(sku, ' ') FROM variant WHERE product_id = p_id), '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(p_short_description, '')), 'B') ||
+			setweight(to_tsvector('english', coalesce((
+				SELECT string_agg(c.name, ' ') FROM product_category pc JOIN category c ON c.id = pc.category_id
+				WHERE pc.product_id = p_id), '')), 'B') ||
+			setweight(to_tsvector('english', coalesce(p_description, '')), 'C')
+	$$`,
+
+	// Only search_vector is set here, so the product trigger below does not fire again
+	`CREATE OR REPLACE FUNCTION refresh_product_search(p_id bigint) RETURNS void LANGUAGE sql AS $$
+		UPDATE product SET search_vector = product_search_document(id, name, short_description, description) WHERE id = p_id
+	$$`,
+
+	`CREATE OR REPLACE FUNCTION product_search_trigger() RETURNS trigger LANGUAGE plpgsql AS $$
+	BEGIN
+		NEW.search_vector := product_search_document(NEW.id, NEW.name, NEW.short_description, NEW.description);
+		RETURN NEW;
+	END
+	$$`,
+	`DROP TRIGGER IF EXISTS product_search_update ON product`,
+	`CREATE TRIGGER product_search_update BEFORE INSERT OR UPDATE OF name, short_description, description ON product
+		FOR EACH ROW EXECUTE FUNCTION product_search_trigger()`,
+
+	// Variants and category assignments refresh the products they belong to, before and after a move
+	`CREATE OR REPLACE FUNCTION product_child_search_trigger() RETURNS trigger LANGUAGE plpgsql AS $$
+	BEGIN
+		IF TG_OP IN ('UPDATE', 'DELETE') THEN
+			PERFORM refresh_product_search(OLD.product_id);
+		END IF;
+		IF TG_OP IN ('INSERT', 'UPDATE') AND (TG_OP = 'INSERT' OR NEW.product_id <> OLD.product_id) THEN
+			PERFORM refresh_product_search(NEW.product_id);
+		END IF;
+		RETURN NULL;
+	END
+	$$`,
+	`DROP TRIGGER IF EXISTS variant_search_update ON variant`,
+	`CREATE TRIGGER variant_search_update AFTER INSERT OR DELETE OR UPDATE OF sku, product_id ON variant
+		FOR EACH ROW EXECUTE FUNCTION product_child_search_trigger()`,
+	`DROP TRIGGER IF EXISTS product_category_search_update ON product_category`,
+	`CREATE TRIGGER product_category_search_update AFTER INSERT OR DELETE OR UPDATE OF product_id, category_id ON product_category
+		FOR EACH ROW EXECUTE FUNCTION product_child_search_trigger()`,
+
+	// A renamed category refreshes every product assigned to it
+	`CREATE OR REPLACE FUNCTION category_search_trigger() RETURNS trigger LANGUAGE plpgsql AS $$
+	BEGIN
+		PERFORM refresh_product_search(pc.product_id) FROM product_category pc WHERE pc.category_id = NEW.id;
+		RETURN NULL;
+	END
+	$$`,
+	`DROP TRIGGER IF EXISTS category_search_update ON category`,
+	`CREATE TRIGGER category_search_update AFTER UPDATE OF name ON category
+		FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name) EXECUTE FUNCTION category_search_trigger()`,
+
+	`UPDATE product SET search_vector = product_search_document(id, name, short_description, description)`,
+	`CREATE INDEX IF NOT EXISTS idx_product_search ON product USING GIN (search_vector)`,
+}
+
+// setupProductSearch installs the search column, triggers and index; safe to re-run
+func setupProductSearch(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range productSearchSQL {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// suggestSQL enables trigram matching for GET /search/suggest. The expression
+// indexes serve both the typo-tolerant <% operator and LIKE on lower(name).
+var suggestSQL = []string{
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	`CREATE INDEX IF NOT EXISTS idx_product_name_trgm ON product USING GIN (lower(name) gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_category_name_trgm ON category USING GIN (lower(name) gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_brand_name_trgm ON brand USING GIN (lower(name) gin_trgm_ops)`,
+}
+
+// setupSearchSuggest installs pg_trgm and the name indexes; safe to re-run.
+// Creating the extension needs a role allowed to do so.
+func setupSearchSuggest(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range suggestSQL {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
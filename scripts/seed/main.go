@@ -2,9 +2,12 @@ package main
 
 import (
 	"log"
+	"os"
+	"strings"
 
 	"github.com/Durgarao310/zneha-backend/internal/database"
 	"github.com/Durgarao310/zneha-backend/internal/model"
+	"github.com/Durgarao310/zneha-backend/pkg/hash"
 )
 
 func main() {
@@ -20,5 +23,37 @@ func main() {
 			log.Fatalf("❌ Failed to seed product: %v", err)
 		}
 	}
+
+	// Admin role with every permission
+	var permissions []model.Permission
+	if err := db.Find(&permissions).Error; err != nil {
+		log.Fatalf("❌ Failed to load permissions: %v", err)
+	}
+	adminRole := model.Role{Name: "admin"}
+	if err := db.Where(adminRole).Attrs(model.Role{Description: "Full administrative access"}).FirstOrCreate(&adminRole).Error; err != nil {
+		log.Fatalf("❌ Failed to seed admin role: %v", err)
+	}
+	if err := db.Model(&adminRole).Association("Permissions").Replace(permissions); err != nil {
+		log.Fatalf("❌ Failed to assign admin permissions: %v", err)
+	}
+
+	// Optional bootstrap admin user
+	// Stored the way the auth service normalizes login emails, or the admin could never log in
+	adminEmail := strings.ToLower(strings.TrimSpace(os.Getenv("SEED_ADMIN_EMAIL")))
+	adminPassword := os.Getenv("SEED_ADMIN_PASSWORD")
+	if adminEmail != "" && adminPassword != "" {
+		passwordHash, err := hash.HashPassword(adminPassword)
+		if err != nil {
+			log.Fatalf("❌ Failed to hash admin password: %v", err)
+		}
+		admin := model.User{Email: adminEmail}
+		if err := db.Where(admin).Attrs(model.User{PasswordHash: passwordHash, Name: "Administrator", IsActive: true}).FirstOrCreate(&admin).Error; err != nil {
+			log.Fatalf("❌ Failed to seed admin user: %v", err)
+		}
+		if err := db.Model(&admin).Association("Roles").Append(&adminRole); err != nil {
+			log.Fatalf("❌ Failed to assign admin role: %v", err)
+		}
+	}
+
 	log.Println("🌱 Database seeded successfully")
 }